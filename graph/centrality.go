@@ -0,0 +1,236 @@
+package graph
+
+import (
+	"math"
+	"slices"
+
+	"github.com/goforces/gollection/queue"
+)
+
+// PageRankOptions configures PageRank. Zero fields take the documented
+// defaults.
+type PageRankOptions[T comparable] struct {
+	// Damping is the probability of following an edge rather than jumping
+	// to a random vertex. Defaults to 0.85.
+	Damping float64
+	// Personalization weights the random jump towards particular vertices.
+	// Vertices not present get weight 0. Defaults to a uniform jump.
+	Personalization map[T]float64
+	// Tolerance stops the iteration once the L1 change between rounds
+	// falls below it. Defaults to 1e-6.
+	Tolerance float64
+	// MaxIterations bounds the number of rounds. Defaults to 100.
+	MaxIterations int
+}
+
+// PageRank computes the PageRank of every vertex. The walker follows an
+// edge with probability proportional to its weight, so weights must be
+// non-negative. Vertices without outgoing weight jump according to the
+// personalization vector. Scores sum to 1.
+func PageRank[T comparable](g *Graph[T], opts PageRankOptions[T]) map[T]float64 {
+	damping := opts.Damping
+	if damping == 0 {
+		damping = 0.85
+	}
+	tol := opts.Tolerance
+	if tol == 0 {
+		tol = 1e-6
+	}
+	maxIter := opts.MaxIterations
+	if maxIter == 0 {
+		maxIter = 100
+	}
+
+	n := len(g.adj)
+	if n == 0 {
+		return map[T]float64{}
+	}
+
+	jump := make(map[T]float64, n)
+	if len(opts.Personalization) > 0 {
+		total := 0.0
+		for v, p := range opts.Personalization {
+			if _, ok := g.adj[v]; ok {
+				total += p
+			}
+		}
+		for v, p := range opts.Personalization {
+			if _, ok := g.adj[v]; ok && total > 0 {
+				jump[v] = p / total
+			}
+		}
+	}
+	if len(jump) == 0 {
+		for v := range g.adj {
+			jump[v] = 1 / float64(n)
+		}
+	}
+
+	outWeight := make(map[T]float64, n)
+	for v, neighbors := range g.adj {
+		for _, w := range neighbors {
+			outWeight[v] += w
+		}
+	}
+
+	rank := make(map[T]float64, n)
+	for v := range g.adj {
+		rank[v] = 1 / float64(n)
+	}
+	for iter := 0; iter < maxIter; iter++ {
+		dangling := 0.0
+		for v, r := range rank {
+			if outWeight[v] == 0 {
+				dangling += r
+			}
+		}
+		next := make(map[T]float64, n)
+		for v := range g.adj {
+			next[v] = (1-damping)*jump[v] + damping*dangling*jump[v]
+		}
+		for u, neighbors := range g.adj {
+			if outWeight[u] == 0 {
+				continue
+			}
+			share := damping * rank[u] / outWeight[u]
+			for v, w := range neighbors {
+				next[v] += share * w
+			}
+		}
+		delta := 0.0
+		for v := range next {
+			delta += math.Abs(next[v] - rank[v])
+		}
+		rank = next
+		if delta < tol {
+			break
+		}
+	}
+	return rank
+}
+
+// DegreeCentrality returns the degree of every vertex divided by the largest
+// possible degree, n-1. For directed graphs in- and out-degree are summed.
+func DegreeCentrality[T comparable](g *Graph[T]) map[T]float64 {
+	out := make(map[T]float64, len(g.adj))
+	n := len(g.adj)
+	if n <= 1 {
+		for v := range g.adj {
+			out[v] = 0
+		}
+		return out
+	}
+	for u, neighbors := range g.adj {
+		out[u] += float64(len(neighbors))
+		if g.directed {
+			for v := range neighbors {
+				out[v]++
+			}
+		}
+	}
+	for v := range out {
+		out[v] /= float64(n - 1)
+	}
+	return out
+}
+
+// ClosenessCentrality returns, for every vertex, the inverse of its average
+// weighted distance to the vertices it can reach, scaled by the fraction of
+// the graph it can reach (Wasserman and Faust). Vertices that reach nothing
+// score 0. Edge weights must be non-negative.
+func ClosenessCentrality[T comparable](g *Graph[T]) map[T]float64 {
+	out := make(map[T]float64, len(g.adj))
+	n := len(g.adj)
+	for v := range g.adj {
+		dist, _ := Dijkstra[T](g, v)
+		total := 0.0
+		for _, d := range dist {
+			total += d
+		}
+		reached := len(dist) - 1
+		if total > 0 && n > 1 {
+			out[v] = float64(reached) / total * float64(reached) / float64(n-1)
+		} else {
+			out[v] = 0
+		}
+	}
+	return out
+}
+
+// BetweennessCentrality returns, for every vertex, the number of cheapest
+// paths between other pairs of vertices that pass through it, each pair
+// contributing in proportion to the share of its cheapest paths that do so.
+// It uses Brandes' algorithm with edge weights as distances, so weights must
+// be non-negative. If normalized is true, scores are divided by the number
+// of pairs that exclude the vertex.
+func BetweennessCentrality[T comparable](g *Graph[T], normalized bool) map[T]float64 {
+	type item struct {
+		v    T
+		dist float64
+	}
+
+	cb := make(map[T]float64, len(g.adj))
+	for v := range g.adj {
+		cb[v] = 0
+	}
+
+	for s := range g.adj {
+		var order []T // vertices in non-decreasing distance from s
+		preds := make(map[T][]T)
+		sigma := map[T]float64{s: 1}
+		dist := map[T]float64{s: 0}
+		done := make(map[T]bool)
+
+		pq := queue.NewPriorityQueue(func(a, b item) bool { return a.dist < b.dist })
+		pq.Push(item{s, 0})
+		for !pq.IsEmpty() {
+			cur, _ := pq.Pop()
+			if done[cur.v] {
+				continue
+			}
+			done[cur.v] = true
+			order = append(order, cur.v)
+			for w, weight := range g.adj[cur.v] {
+				d := cur.dist + weight
+				old, seen := dist[w]
+				switch {
+				case !seen || d < old:
+					dist[w] = d
+					sigma[w] = sigma[cur.v]
+					preds[w] = append(preds[w][:0], cur.v)
+					pq.Push(item{w, d})
+				case d == old && !done[w]:
+					sigma[w] += sigma[cur.v]
+					preds[w] = append(preds[w], cur.v)
+				}
+			}
+		}
+
+		delta := make(map[T]float64, len(order))
+		for _, w := range slices.Backward(order) {
+			for _, v := range preds[w] {
+				delta[v] += sigma[v] / sigma[w] * (1 + delta[w])
+			}
+			if w != s {
+				cb[w] += delta[w]
+			}
+		}
+	}
+
+	n := float64(len(g.adj))
+	scale := 1.0
+	if !g.directed {
+		scale = 0.5 // every pair was counted from both ends
+	}
+	if normalized && n > 2 {
+		if g.directed {
+			scale /= (n - 1) * (n - 2)
+		} else {
+			scale /= (n - 1) * (n - 2) / 2
+		}
+	}
+	for v := range cb {
+		cb[v] *= scale
+	}
+	return cb
+}
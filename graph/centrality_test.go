@@ -0,0 +1,204 @@
+package graph
+
+import (
+	"math"
+	"testing"
+)
+
+const eps = 1e-6
+
+func approxEqual(a, b float64) bool { return math.Abs(a-b) < eps }
+
+func checkScores[T comparable](t *testing.T, got, want map[T]float64) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("got %d scores, want %d: %v", len(got), len(want), got)
+	}
+	for v, w := range want {
+		if !approxEqual(got[v], w) {
+			t.Errorf("score(%v) = %v, want %v", v, got[v], w)
+		}
+	}
+}
+
+func TestPageRankCycle(t *testing.T) {
+	g := New[int](true)
+	g.AddEdge(1, 2, 1)
+	g.AddEdge(2, 3, 1)
+	g.AddEdge(3, 1, 1)
+
+	pr := PageRank(g, PageRankOptions[int]{})
+	checkScores(t, pr, map[int]float64{1: 1.0 / 3, 2: 1.0 / 3, 3: 1.0 / 3})
+}
+
+func TestPageRankRanksHubs(t *testing.T) {
+	g := New[string](true)
+	for _, svc := range []string{"auth", "orders", "billing", "search"} {
+		g.AddEdge(svc, "db", 1)
+	}
+	g.AddEdge("orders", "billing", 1)
+	g.AddEdge("db", "cache", 1)
+
+	pr := PageRank(g, PageRankOptions[string]{Tolerance: 1e-10})
+
+	total := 0.0
+	for _, r := range pr {
+		total += r
+	}
+	if !approxEqual(total, 1) {
+		t.Errorf("scores sum to %v, want 1", total)
+	}
+	if pr["db"] <= pr["auth"] || pr["db"] <= pr["billing"] {
+		t.Errorf("db should outrank its callers: %v", pr)
+	}
+	if pr["billing"] <= pr["auth"] {
+		t.Errorf("billing has an extra caller and should outrank auth: %v", pr)
+	}
+	if pr["cache"] <= pr["auth"] {
+		t.Errorf("cache is fed by db and should outrank auth: %v", pr)
+	}
+}
+
+func TestPageRankWeights(t *testing.T) {
+	g := New[string](true)
+	g.AddEdge("a", "b", 3)
+	g.AddEdge("a", "c", 1)
+	g.AddEdge("b", "a", 1)
+	g.AddEdge("c", "a", 1)
+
+	pr := PageRank(g, PageRankOptions[string]{Tolerance: 1e-10})
+	if pr["b"] <= pr["c"] {
+		t.Errorf("heavier edge should carry more rank: %v", pr)
+	}
+}
+
+func TestPageRankPersonalization(t *testing.T) {
+	g := New[int](false)
+	g.AddEdge(1, 2, 1)
+	g.AddEdge(2, 3, 1)
+	g.AddEdge(3, 4, 1)
+
+	plain := PageRank(g, PageRankOptions[int]{})
+	biased := PageRank(g, PageRankOptions[int]{
+		Personalization: map[int]float64{1: 1},
+		Damping:         0.5,
+	})
+	if biased[1] <= plain[1] {
+		t.Errorf("personalized vertex should gain rank: plain=%v biased=%v", plain[1], biased[1])
+	}
+	if biased[4] >= plain[4] {
+		t.Errorf("distant vertex should lose rank: plain=%v biased=%v", plain[4], biased[4])
+	}
+}
+
+func TestPageRankEmpty(t *testing.T) {
+	if pr := PageRank(New[int](true), PageRankOptions[int]{}); len(pr) != 0 {
+		t.Errorf("empty graph PageRank = %v", pr)
+	}
+}
+
+func TestPageRankMaxIterations(t *testing.T) {
+	g := New[int](true)
+	g.AddEdge(1, 2, 1)
+	g.AddEdge(2, 2, 1)
+
+	one := PageRank(g, PageRankOptions[int]{MaxIterations: 1})
+	// After one round from uniform: 1 gets only the random jump.
+	if !approxEqual(one[1], 0.15/2) {
+		t.Errorf("rank(1) after one iteration = %v, want %v", one[1], 0.15/2)
+	}
+}
+
+func TestDegreeCentrality(t *testing.T) {
+	t.Run("undirected star", func(t *testing.T) {
+		g := New[int](false)
+		g.AddEdge(0, 1, 1)
+		g.AddEdge(0, 2, 1)
+		g.AddEdge(0, 3, 1)
+		checkScores(t, DegreeCentrality(g), map[int]float64{0: 1, 1: 1.0 / 3, 2: 1.0 / 3, 3: 1.0 / 3})
+	})
+
+	t.Run("directed", func(t *testing.T) {
+		g := New[int](true)
+		g.AddEdge(1, 2, 1)
+		g.AddEdge(1, 3, 1)
+		checkScores(t, DegreeCentrality(g), map[int]float64{1: 1, 2: 0.5, 3: 0.5})
+	})
+
+	t.Run("single vertex", func(t *testing.T) {
+		g := New[int](false)
+		g.AddVertex(1)
+		checkScores(t, DegreeCentrality(g), map[int]float64{1: 0})
+	})
+}
+
+func TestClosenessCentrality(t *testing.T) {
+	g := New[int](false)
+	g.AddEdge(1, 2, 1)
+	g.AddEdge(2, 3, 1)
+	checkScores(t, ClosenessCentrality(g), map[int]float64{1: 2.0 / 3, 2: 1, 3: 2.0 / 3})
+
+	// An isolated vertex reaches nothing and scales down everyone else.
+	g.AddVertex(4)
+	checkScores(t, ClosenessCentrality(g), map[int]float64{1: 4.0 / 9, 2: 2.0 / 3, 3: 4.0 / 9, 4: 0})
+}
+
+func TestBetweennessCentrality(t *testing.T) {
+	t.Run("star", func(t *testing.T) {
+		g := New[int](false)
+		for i := 1; i <= 4; i++ {
+			g.AddEdge(0, i, 1)
+		}
+		checkScores(t, BetweennessCentrality(g, false), map[int]float64{0: 6, 1: 0, 2: 0, 3: 0, 4: 0})
+		checkScores(t, BetweennessCentrality(g, true), map[int]float64{0: 1, 1: 0, 2: 0, 3: 0, 4: 0})
+	})
+
+	t.Run("directed path", func(t *testing.T) {
+		g := New[int](true)
+		g.AddEdge(1, 2, 1)
+		g.AddEdge(2, 3, 1)
+		checkScores(t, BetweennessCentrality(g, false), map[int]float64{1: 0, 2: 1, 3: 0})
+		checkScores(t, BetweennessCentrality(g, true), map[int]float64{1: 0, 2: 0.5, 3: 0})
+	})
+
+	t.Run("equal paths split credit", func(t *testing.T) {
+		g := New[int](false)
+		g.AddEdge(1, 2, 1)
+		g.AddEdge(2, 3, 1)
+		g.AddEdge(3, 4, 1)
+		g.AddEdge(4, 1, 1)
+		checkScores(t, BetweennessCentrality(g, false), map[int]float64{1: 0.5, 2: 0.5, 3: 0.5, 4: 0.5})
+	})
+
+	t.Run("weights", func(t *testing.T) {
+		g := New[string](false)
+		g.AddEdge("A", "B", 1)
+		g.AddEdge("B", "C", 1)
+		g.AddEdge("A", "C", 5)
+		checkScores(t, BetweennessCentrality(g, false), map[string]float64{"A": 0, "B": 1, "C": 0})
+	})
+}
+
+func BenchmarkPageRank(b *testing.B) {
+	g := New[int](true)
+	for i := 0; i < 1000; i++ {
+		g.AddEdge(i, (i+1)%1000, 1)
+		g.AddEdge(i, (i*7)%1000, 1)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		PageRank(g, PageRankOptions[int]{})
+	}
+}
+
+func BenchmarkBetweennessCentrality(b *testing.B) {
+	g := New[int](false)
+	for i := 0; i < 200; i++ {
+		g.AddEdge(i, (i+1)%200, 1)
+		g.AddEdge(i, (i*7)%200, 1)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		BetweennessCentrality(g, true)
+	}
+}
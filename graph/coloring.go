@@ -0,0 +1,86 @@
+package graph
+
+import "slices"
+
+// The coloring algorithms treat the graph as undirected and ignore
+// self-loops. Colors are numbered from 0 and adjacent vertices always get
+// different colors.
+
+// GreedyColoring colors vertices in order of decreasing degree (Welsh-Powell),
+// giving each the smallest color not used by its neighbors. Ties are broken
+// by insertion order.
+func GreedyColoring[T comparable](g *Graph[T]) map[T]int {
+	vertices, wg := undirectedView(g)
+	order := make([]int, len(vertices))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(a, b int) int { return len(wg.adj[b]) - len(wg.adj[a]) })
+
+	colors := make([]int, len(vertices))
+	for i := range colors {
+		colors[i] = -1
+	}
+	for _, i := range order {
+		colors[i] = smallestFreeColor(wg, colors, i)
+	}
+	return colorMap(vertices, colors)
+}
+
+// DSaturColoring colors vertices using Brélaz's DSatur heuristic: the next
+// vertex is the one whose neighbors already use the most distinct colors,
+// with ties broken by degree and then insertion order. It usually needs
+// fewer colors than GreedyColoring.
+func DSaturColoring[T comparable](g *Graph[T]) map[T]int {
+	vertices, wg := undirectedView(g)
+	n := len(vertices)
+	colors := make([]int, n)
+	saturation := make([]map[int]bool, n)
+	for i := range colors {
+		colors[i] = -1
+		saturation[i] = make(map[int]bool)
+	}
+
+	for colored := 0; colored < n; colored++ {
+		next := -1
+		for i := 0; i < n; i++ {
+			if colors[i] >= 0 {
+				continue
+			}
+			if next < 0 ||
+				len(saturation[i]) > len(saturation[next]) ||
+				len(saturation[i]) == len(saturation[next]) && len(wg.adj[i]) > len(wg.adj[next]) {
+				next = i
+			}
+		}
+		c := smallestFreeColor(wg, colors, next)
+		colors[next] = c
+		for _, a := range wg.adj[next] {
+			saturation[a.to][c] = true
+		}
+	}
+	return colorMap(vertices, colors)
+}
+
+// smallestFreeColor returns the lowest color not used by a neighbor of i.
+func smallestFreeColor(wg *weighted, colors []int, i int) int {
+	used := make([]bool, len(wg.adj[i])+1)
+	for _, a := range wg.adj[i] {
+		if c := colors[a.to]; c >= 0 && c < len(used) {
+			used[c] = true
+		}
+	}
+	c := 0
+	for used[c] {
+		c++
+	}
+	return c
+}
+
+func colorMap[T comparable](vertices []T, colors []int) map[T]int {
+	out := make(map[T]int, len(vertices))
+	for i, v := range vertices {
+		out[v] = colors[i]
+	}
+	return out
+}
@@ -0,0 +1,90 @@
+package graph
+
+import (
+	"math/rand/v2"
+	"testing"
+)
+
+func checkColoring[T comparable](t *testing.T, g *Graph[T], colors map[T]int) int {
+	t.Helper()
+	if len(colors) != len(g.Vertices()) {
+		t.Fatalf("colored %d vertices, want %d", len(colors), len(g.Vertices()))
+	}
+	used := make(map[int]bool)
+	for _, u := range g.Vertices() {
+		used[colors[u]] = true
+		for v := range g.Neighbors(u) {
+			if u != v && colors[u] == colors[v] {
+				t.Errorf("adjacent vertices %v and %v share color %d", u, v, colors[u])
+			}
+		}
+	}
+	return len(used)
+}
+
+func TestGreedyColoring(t *testing.T) {
+	g := New[int](false)
+	// Odd cycle needs three colors.
+	for i := 0; i < 5; i++ {
+		g.AddEdge(i, (i+1)%5, 1)
+	}
+	if n := checkColoring(t, g, GreedyColoring(g)); n != 3 {
+		t.Errorf("5-cycle used %d colors, want 3", n)
+	}
+
+	bip := New[string](false)
+	bip.AddEdge("a", "x", 1)
+	bip.AddEdge("a", "y", 1)
+	bip.AddEdge("b", "x", 1)
+	bip.AddEdge("b", "y", 1)
+	if n := checkColoring(t, bip, GreedyColoring(bip)); n != 2 {
+		t.Errorf("bipartite graph used %d colors, want 2", n)
+	}
+}
+
+func TestDSaturColoring(t *testing.T) {
+	// Crown graph: greedy in a bad order needs n colors, DSatur needs 2.
+	g := New[int](false)
+	for i := 0; i < 5; i++ {
+		for j := 0; j < 5; j++ {
+			if i != j {
+				g.AddEdge(i, 10+j, 1)
+			}
+		}
+	}
+	if n := checkColoring(t, g, DSaturColoring(g)); n != 2 {
+		t.Errorf("crown graph used %d colors, want 2", n)
+	}
+
+	k4 := New[int](true)
+	for i := 0; i < 4; i++ {
+		for j := i + 1; j < 4; j++ {
+			k4.AddEdge(i, j, 1)
+		}
+	}
+	k4.AddEdge(0, 0, 1) // self-loops are ignored
+	if n := checkColoring(t, k4, DSaturColoring(k4)); n != 4 {
+		t.Errorf("K4 used %d colors, want 4", n)
+	}
+}
+
+func TestColoringRandom(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	g := New[int](false)
+	for i := 0; i < 500; i++ {
+		g.AddEdge(r.IntN(80), r.IntN(80), 1)
+	}
+	checkColoring(t, g, GreedyColoring(g))
+	checkColoring(t, g, DSaturColoring(g))
+}
+
+func TestColoringEmpty(t *testing.T) {
+	g := New[int](false)
+	if len(GreedyColoring(g)) != 0 || len(DSaturColoring(g)) != 0 {
+		t.Error("empty graph should have no colors")
+	}
+	g.AddVertex(1)
+	if GreedyColoring(g)[1] != 0 || DSaturColoring(g)[1] != 0 {
+		t.Error("isolated vertex should get color 0")
+	}
+}
@@ -0,0 +1,325 @@
+package graph
+
+import (
+	"math/rand/v2"
+	"slices"
+)
+
+// The algorithms in this file treat the graph as undirected. For directed
+// graphs the weights of u->v and v->u are summed into a single edge.
+
+// arc is one entry of an index-based adjacency list.
+type arc struct {
+	to int
+	w  float64
+}
+
+// weighted is an undirected weighted graph over vertex indices with
+// adjacency lists sorted by target, so iteration order is deterministic.
+type weighted struct {
+	adj  [][]arc   // neighbors other than the vertex itself
+	self []float64 // A_ii: twice the weight of the self-loop, if any
+}
+
+func (wg *weighted) degree(i int) float64 {
+	k := wg.self[i]
+	for _, a := range wg.adj[i] {
+		k += a.w
+	}
+	return k
+}
+
+// undirectedView indexes the vertices of g in insertion order and builds
+// the symmetric weighted adjacency used by the community algorithms.
+func undirectedView[T comparable](g *Graph[T]) ([]T, *weighted) {
+	vertices := g.Vertices()
+	index := make(map[T]int, len(vertices))
+	for i, v := range vertices {
+		index[v] = i
+	}
+
+	sym := make([]map[int]float64, len(vertices))
+	for i := range sym {
+		sym[i] = make(map[int]float64)
+	}
+	wg := &weighted{adj: make([][]arc, len(vertices)), self: make([]float64, len(vertices))}
+	for i, u := range vertices {
+		for v, w := range g.adj[u] {
+			j := index[v]
+			switch {
+			case i == j:
+				wg.self[i] += 2 * w
+			case g.directed:
+				sym[i][j] += w
+				sym[j][i] += w
+			default:
+				sym[i][j] = w
+			}
+		}
+	}
+	for i, m := range sym {
+		for j, w := range m {
+			wg.adj[i] = append(wg.adj[i], arc{j, w})
+		}
+		slices.SortFunc(wg.adj[i], func(a, b arc) int { return a.to - b.to })
+	}
+	return vertices, wg
+}
+
+// Triangles returns the number of triangles each vertex belongs to.
+// Weights and self-loops are ignored.
+func Triangles[T comparable](g *Graph[T]) map[T]int {
+	vertices, wg := undirectedView(g)
+	out := make(map[T]int, len(vertices))
+	for i, v := range vertices {
+		out[v] = triangles(wg, i)
+	}
+	return out
+}
+
+func triangles(wg *weighted, i int) int {
+	count := 0
+	for x, a := range wg.adj[i] {
+		for _, b := range wg.adj[i][x+1:] {
+			if _, ok := slices.BinarySearchFunc(wg.adj[a.to], b.to, func(e arc, t int) int { return e.to - t }); ok {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+// ClusteringCoefficient returns the local clustering coefficient of every
+// vertex: the fraction of pairs of its neighbors that are themselves
+// adjacent. Vertices with fewer than two neighbors score 0.
+func ClusteringCoefficient[T comparable](g *Graph[T]) map[T]float64 {
+	vertices, wg := undirectedView(g)
+	out := make(map[T]float64, len(vertices))
+	for i, v := range vertices {
+		d := len(wg.adj[i])
+		if d < 2 {
+			out[v] = 0
+			continue
+		}
+		out[v] = 2 * float64(triangles(wg, i)) / float64(d*(d-1))
+	}
+	return out
+}
+
+// Modularity returns the weighted modularity of the partition of g given by
+// community, which maps every vertex to a community label.
+func Modularity[T comparable](g *Graph[T], community map[T]int) float64 {
+	vertices, wg := undirectedView(g)
+	labels := make([]int, len(vertices))
+	for i, v := range vertices {
+		labels[i] = community[v]
+	}
+	return modularity(wg, labels)
+}
+
+func modularity(wg *weighted, labels []int) float64 {
+	internal := make(map[int]float64)
+	total := make(map[int]float64)
+	m2 := 0.0
+	for i := range wg.adj {
+		k := wg.degree(i)
+		m2 += k
+		total[labels[i]] += k
+		internal[labels[i]] += wg.self[i]
+		for _, a := range wg.adj[i] {
+			if labels[a.to] == labels[i] {
+				internal[labels[i]] += a.w
+			}
+		}
+	}
+	if m2 == 0 {
+		return 0
+	}
+	q := 0.0
+	for c, tot := range total {
+		q += internal[c]/m2 - (tot/m2)*(tot/m2)
+	}
+	return q
+}
+
+// LabelPropagation detects communities by repeatedly giving each vertex the
+// label carrying the most edge weight among its neighbors. Vertices are
+// visited and ties are broken using rng, so the result is deterministic for
+// a given seed. The returned labels are numbered from 0.
+func LabelPropagation[T comparable](g *Graph[T], rng *rand.Rand) map[T]int {
+	const maxRounds = 100
+
+	vertices, wg := undirectedView(g)
+	labels := make([]int, len(vertices))
+	for i := range labels {
+		labels[i] = i
+	}
+
+	for round := 0; round < maxRounds; round++ {
+		changed := false
+		for _, i := range rng.Perm(len(vertices)) {
+			if len(wg.adj[i]) == 0 {
+				continue
+			}
+			weight := make(map[int]float64)
+			for _, a := range wg.adj[i] {
+				weight[labels[a.to]] += a.w
+			}
+			best := weight[labels[i]]
+			for _, w := range weight {
+				best = max(best, w)
+			}
+			if weight[labels[i]] == best {
+				continue // keep the current label on ties
+			}
+			var ties []int
+			for l, w := range weight {
+				if w == best {
+					ties = append(ties, l)
+				}
+			}
+			slices.Sort(ties)
+			labels[i] = ties[rng.IntN(len(ties))]
+			changed = true
+		}
+		if !changed {
+			break
+		}
+	}
+	return relabel(vertices, labels)
+}
+
+// Louvain detects communities by greedy modularity optimisation using the
+// Louvain method: vertices are moved between neighboring communities while
+// modularity improves, then each community is collapsed into a single
+// vertex and the process repeats. Vertices are visited in an order drawn
+// from rng, so the result is deterministic for a given seed. The returned
+// labels are numbered from 0.
+func Louvain[T comparable](g *Graph[T], rng *rand.Rand) map[T]int {
+	vertices, wg := undirectedView(g)
+	labels := make([]int, len(vertices))
+	for i := range labels {
+		labels[i] = i
+	}
+
+	for {
+		comm, moved := louvainLevel(wg, rng)
+		if !moved {
+			break
+		}
+		// Renumber communities by first appearance and fold them in.
+		ids := make(map[int]int)
+		for _, c := range comm {
+			if _, ok := ids[c]; !ok {
+				ids[c] = len(ids)
+			}
+		}
+		if len(ids) == len(comm) {
+			break
+		}
+		for i := range comm {
+			comm[i] = ids[comm[i]]
+		}
+		for i := range labels {
+			labels[i] = comm[labels[i]]
+		}
+		wg = aggregate(wg, comm, len(ids))
+	}
+	return relabel(vertices, labels)
+}
+
+// louvainLevel runs local moving on wg until no vertex changes community
+// and reports whether any vertex moved at all.
+func louvainLevel(wg *weighted, rng *rand.Rand) ([]int, bool) {
+	n := len(wg.adj)
+	comm := make([]int, n)
+	k := make([]float64, n)
+	tot := make([]float64, n)
+	m2 := 0.0
+	for i := range comm {
+		comm[i] = i
+		k[i] = wg.degree(i)
+		tot[i] = k[i]
+		m2 += k[i]
+	}
+	if m2 == 0 {
+		return comm, false
+	}
+
+	moved := false
+	for {
+		improved := false
+		for _, i := range rng.Perm(n) {
+			links := make(map[int]float64)
+			var candidates []int
+			for _, a := range wg.adj[i] {
+				c := comm[a.to]
+				if _, ok := links[c]; !ok {
+					candidates = append(candidates, c)
+				}
+				links[c] += a.w
+			}
+			slices.Sort(candidates)
+
+			cur := comm[i]
+			tot[cur] -= k[i]
+			best, bestGain := cur, links[cur]-tot[cur]*k[i]/m2
+			for _, c := range candidates {
+				if gain := links[c] - tot[c]*k[i]/m2; gain > bestGain+1e-12 {
+					best, bestGain = c, gain
+				}
+			}
+			tot[best] += k[i]
+			if best != cur {
+				comm[i] = best
+				improved = true
+				moved = true
+			}
+		}
+		if !improved {
+			return comm, moved
+		}
+	}
+}
+
+// aggregate collapses every community of wg into a single vertex.
+func aggregate(wg *weighted, comm []int, n int) *weighted {
+	sym := make([]map[int]float64, n)
+	for i := range sym {
+		sym[i] = make(map[int]float64)
+	}
+	out := &weighted{adj: make([][]arc, n), self: make([]float64, n)}
+	for i, arcs := range wg.adj {
+		ci := comm[i]
+		out.self[ci] += wg.self[i]
+		for _, a := range arcs {
+			if cj := comm[a.to]; cj == ci {
+				out.self[ci] += a.w
+			} else {
+				sym[ci][cj] += a.w
+			}
+		}
+	}
+	for i, m := range sym {
+		for j, w := range m {
+			out.adj[i] = append(out.adj[i], arc{j, w})
+		}
+		slices.SortFunc(out.adj[i], func(a, b arc) int { return a.to - b.to })
+	}
+	return out
+}
+
+// relabel numbers labels from 0 in order of first appearance.
+func relabel[T comparable](vertices []T, labels []int) map[T]int {
+	ids := make(map[int]int)
+	out := make(map[T]int, len(vertices))
+	for i, v := range vertices {
+		id, ok := ids[labels[i]]
+		if !ok {
+			id = len(ids)
+			ids[labels[i]] = id
+		}
+		out[v] = id
+	}
+	return out
+}
@@ -0,0 +1,212 @@
+package graph
+
+import (
+	"math/rand/v2"
+	"testing"
+)
+
+// twoCliques builds two 5-cliques {0..4} and {5..9} joined by the edge 4-5.
+func twoCliques(directed bool) *Graph[int] {
+	g := New[int](directed)
+	for base := 0; base <= 5; base += 5 {
+		for i := base; i < base+5; i++ {
+			for j := i + 1; j < base+5; j++ {
+				g.AddEdge(i, j, 1)
+			}
+		}
+	}
+	g.AddEdge(4, 5, 1)
+	return g
+}
+
+func checkTwoCommunities(t *testing.T, labels map[int]int) {
+	t.Helper()
+	if len(labels) != 10 {
+		t.Fatalf("got %d labels, want 10", len(labels))
+	}
+	for i := 1; i < 5; i++ {
+		if labels[i] != labels[0] {
+			t.Errorf("vertex %d should share a community with 0: %v", i, labels)
+		}
+		if labels[i+5] != labels[5] {
+			t.Errorf("vertex %d should share a community with 5: %v", i+5, labels)
+		}
+	}
+	if labels[0] == labels[5] {
+		t.Errorf("cliques should be separate communities: %v", labels)
+	}
+}
+
+func sameLabels(a, b map[int]int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for v, l := range a {
+		if b[v] != l {
+			return false
+		}
+	}
+	return true
+}
+
+func TestTriangles(t *testing.T) {
+	g := New[int](false)
+	// K4 on {1,2,3,4} plus a pendant vertex 5
+	for i := 1; i <= 4; i++ {
+		for j := i + 1; j <= 4; j++ {
+			g.AddEdge(i, j, 1)
+		}
+	}
+	g.AddEdge(4, 5, 1)
+	g.AddEdge(5, 5, 1) // self-loops are ignored
+
+	got := Triangles(g)
+	want := map[int]int{1: 3, 2: 3, 3: 3, 4: 3, 5: 0}
+	for v, n := range want {
+		if got[v] != n {
+			t.Errorf("Triangles(%d) = %d, want %d", v, got[v], n)
+		}
+	}
+}
+
+func TestClusteringCoefficient(t *testing.T) {
+	g := New[int](false)
+	g.AddEdge(1, 2, 1)
+	g.AddEdge(1, 3, 1)
+	g.AddEdge(1, 4, 1)
+	g.AddEdge(2, 3, 1)
+	g.AddVertex(5)
+
+	checkScores(t, ClusteringCoefficient(g), map[int]float64{
+		1: 1.0 / 3, // one of three neighbor pairs is linked
+		2: 1,
+		3: 1,
+		4: 0,
+		5: 0,
+	})
+}
+
+func TestClusteringCoefficientDirected(t *testing.T) {
+	g := New[int](true)
+	g.AddEdge(1, 2, 1)
+	g.AddEdge(2, 3, 1)
+	g.AddEdge(3, 1, 1)
+	checkScores(t, ClusteringCoefficient(g), map[int]float64{1: 1, 2: 1, 3: 1})
+}
+
+func TestModularity(t *testing.T) {
+	g := New[int](false)
+	g.AddEdge(1, 2, 1)
+	g.AddEdge(2, 3, 1)
+	g.AddEdge(3, 1, 1)
+	g.AddEdge(4, 5, 1)
+	g.AddEdge(5, 6, 1)
+	g.AddEdge(6, 4, 1)
+
+	split := map[int]int{1: 0, 2: 0, 3: 0, 4: 1, 5: 1, 6: 1}
+	if q := Modularity(g, split); !approxEqual(q, 0.5) {
+		t.Errorf("modularity = %v, want 0.5", q)
+	}
+	single := map[int]int{1: 0, 2: 0, 3: 0, 4: 0, 5: 0, 6: 0}
+	if q := Modularity(g, single); !approxEqual(q, 0) {
+		t.Errorf("modularity of one community = %v, want 0", q)
+	}
+	if q := Modularity(New[int](false), nil); q != 0 {
+		t.Errorf("modularity of empty graph = %v, want 0", q)
+	}
+}
+
+func TestLouvain(t *testing.T) {
+	for _, directed := range []bool{false, true} {
+		g := twoCliques(directed)
+		labels := Louvain(g, rand.New(rand.NewPCG(1, 2)))
+		checkTwoCommunities(t, labels)
+	}
+}
+
+func TestLouvainWeights(t *testing.T) {
+	// A 4-cycle whose heavy edges pair up {1,2} and {3,4}.
+	g := New[int](false)
+	g.AddEdge(1, 2, 10)
+	g.AddEdge(2, 3, 1)
+	g.AddEdge(3, 4, 10)
+	g.AddEdge(4, 1, 1)
+
+	labels := Louvain(g, rand.New(rand.NewPCG(7, 7)))
+	if labels[1] != labels[2] || labels[3] != labels[4] || labels[1] == labels[3] {
+		t.Errorf("labels = %v, want {1,2} and {3,4}", labels)
+	}
+}
+
+func TestLouvainDeterministic(t *testing.T) {
+	g := New[int](false)
+	r := rand.New(rand.NewPCG(3, 4))
+	for i := 0; i < 300; i++ {
+		g.AddEdge(r.IntN(60), r.IntN(60), float64(1+r.IntN(5)))
+	}
+
+	first := Louvain(g, rand.New(rand.NewPCG(42, 0)))
+	for i := 0; i < 5; i++ {
+		if again := Louvain(g, rand.New(rand.NewPCG(42, 0))); !sameLabels(first, again) {
+			t.Fatal("Louvain should be deterministic for a fixed seed")
+		}
+	}
+
+	singletons := make(map[int]int)
+	for i, v := range g.Vertices() {
+		singletons[v] = i
+	}
+	if Modularity(g, first) <= Modularity(g, singletons) {
+		t.Error("Louvain should improve on the singleton partition")
+	}
+}
+
+func TestLouvainEdgeless(t *testing.T) {
+	g := New[string](false)
+	g.AddVertex("a")
+	g.AddVertex("b")
+
+	labels := Louvain(g, rand.New(rand.NewPCG(1, 1)))
+	if labels["a"] == labels["b"] {
+		t.Errorf("isolated vertices should stay apart: %v", labels)
+	}
+}
+
+func TestLabelPropagation(t *testing.T) {
+	g := twoCliques(false)
+	labels := LabelPropagation(g, rand.New(rand.NewPCG(5, 6)))
+	checkTwoCommunities(t, labels)
+
+	for i := 0; i < 5; i++ {
+		if again := LabelPropagation(g, rand.New(rand.NewPCG(5, 6))); !sameLabels(labels, again) {
+			t.Fatal("LabelPropagation should be deterministic for a fixed seed")
+		}
+	}
+}
+
+func TestLabelPropagationComponents(t *testing.T) {
+	g := New[int](false)
+	g.AddEdge(1, 2, 1)
+	g.AddEdge(3, 4, 1)
+	g.AddVertex(5)
+
+	labels := LabelPropagation(g, rand.New(rand.NewPCG(9, 9)))
+	if labels[1] != labels[2] || labels[3] != labels[4] {
+		t.Errorf("connected pairs should share labels: %v", labels)
+	}
+	if labels[1] == labels[3] || labels[5] == labels[1] || labels[5] == labels[3] {
+		t.Errorf("components should have distinct labels: %v", labels)
+	}
+}
+
+func BenchmarkLouvain(b *testing.B) {
+	g := New[int](false)
+	r := rand.New(rand.NewPCG(1, 1))
+	for i := 0; i < 5000; i++ {
+		g.AddEdge(r.IntN(1000), r.IntN(1000), 1)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Louvain(g, rand.New(rand.NewPCG(1, 1)))
+	}
+}
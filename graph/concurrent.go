@@ -0,0 +1,144 @@
+package graph
+
+import (
+	"maps"
+	"slices"
+	"sync"
+	"sync/atomic"
+)
+
+// Concurrent is a Graph that is safe for concurrent use. Writers are
+// serialised by a mutex and each write publishes an immutable snapshot of
+// the graph. Reads load the latest snapshot atomically and never wait for
+// writers, so algorithms such as PageRank or BFS can run on Snapshot while
+// writes continue.
+//
+// Snapshots are copy-on-write: publishing one copies the top-level vertex
+// map and the adjacency maps of the vertices the write touched, and shares
+// everything else with the previous snapshot. A write therefore costs O(V)
+// plus the degree of the vertices it changes; use Update to batch several
+// changes into one publication. Concurrent keeps its own working copy of
+// the graph next to the snapshot, so it uses about twice the memory of a
+// Graph.
+type Concurrent[T comparable] struct {
+	mu      sync.Mutex
+	g       *Graph[T]      // working copy, only used with mu held
+	touched map[T]struct{} // vertices whose adjacency changed in this write
+	resized bool           // whether this write added or removed a vertex
+	snap    atomic.Pointer[Graph[T]]
+}
+
+// NewConcurrent creates an empty concurrent graph. If directed is true,
+// edges are one-way.
+func NewConcurrent[T comparable](directed bool) *Concurrent[T] {
+	c := &Concurrent[T]{g: New[T](directed), touched: make(map[T]struct{})}
+	c.g.Observe(c.record)
+	c.snap.Store(New[T](directed))
+	return c
+}
+
+// Directed reports whether edges are one-way.
+func (c *Concurrent[T]) Directed() bool { return c.g.directed }
+
+// AddVertex ensures the vertex exists.
+func (c *Concurrent[T]) AddVertex(v T) {
+	c.Update(func(g *Graph[T]) { g.AddVertex(v) })
+}
+
+// AddEdge adds an edge u->v with weight w. For undirected graphs, adds both ways.
+func (c *Concurrent[T]) AddEdge(u, v T, w float64) {
+	c.Update(func(g *Graph[T]) { g.AddEdge(u, v, w) })
+}
+
+// RemoveVertex removes a vertex and all edges connected to it.
+func (c *Concurrent[T]) RemoveVertex(v T) {
+	c.Update(func(g *Graph[T]) { g.RemoveVertex(v) })
+}
+
+// RemoveEdge removes an edge from u to v.
+// For undirected graphs, removes both u->v and v->u.
+func (c *Concurrent[T]) RemoveEdge(u, v T) {
+	c.Update(func(g *Graph[T]) { g.RemoveEdge(u, v) })
+}
+
+// Update calls fn with the underlying graph while holding the write lock,
+// so that several changes are applied atomically and published as one
+// snapshot. fn must not retain g or call methods of c.
+func (c *Concurrent[T]) Update(fn func(g *Graph[T])) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	fn(c.g)
+	c.publish()
+}
+
+// record notes which parts of the working copy an event changed.
+func (c *Concurrent[T]) record(e Event[T]) {
+	switch e.Kind {
+	case VertexAdded, VertexRemoved:
+		c.touched[e.Vertex] = struct{}{}
+		c.resized = true
+	default:
+		c.touched[e.Edge.From] = struct{}{}
+		if !c.g.directed {
+			c.touched[e.Edge.To] = struct{}{}
+		}
+	}
+}
+
+// publish stores a new snapshot that shares the adjacency maps of
+// untouched vertices with the previous one.
+func (c *Concurrent[T]) publish() {
+	if len(c.touched) == 0 {
+		return
+	}
+	prev := c.snap.Load()
+	next := &Graph[T]{directed: prev.directed, adj: maps.Clone(prev.adj), order: prev.order}
+	for v := range c.touched {
+		if neighbors, ok := c.g.adj[v]; ok {
+			next.adj[v] = maps.Clone(neighbors)
+		} else {
+			delete(next.adj, v)
+		}
+	}
+	if c.resized {
+		next.order = slices.Clone(c.g.order)
+	}
+	c.snap.Store(next)
+	clear(c.touched)
+	c.resized = false
+}
+
+// HasVertex reports whether v is in the graph.
+func (c *Concurrent[T]) HasVertex(v T) bool {
+	return c.snap.Load().HasVertex(v)
+}
+
+// Weight returns the weight of the edge u->v.
+func (c *Concurrent[T]) Weight(u, v T) (float64, bool) {
+	w, ok := c.snap.Load().adj[u][v]
+	return w, ok
+}
+
+// Neighbors returns a copy of the neighbor-weight map for v, or nil if v is
+// not in the graph.
+func (c *Concurrent[T]) Neighbors(v T) map[T]float64 {
+	return maps.Clone(c.snap.Load().adj[v])
+}
+
+// Vertices returns all vertices in the order they were added.
+func (c *Concurrent[T]) Vertices() []T {
+	return c.snap.Load().Vertices()
+}
+
+// Order returns the number of vertices.
+func (c *Concurrent[T]) Order() int {
+	return len(c.snap.Load().order)
+}
+
+// Snapshot returns the graph as of the last completed write, with a single
+// atomic load. The snapshot is immutable and shared with other callers and
+// with later snapshots, so it must not be modified; Clone it first if
+// needed.
+func (c *Concurrent[T]) Snapshot() *Graph[T] {
+	return c.snap.Load()
+}
@@ -0,0 +1,173 @@
+package graph
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestConcurrentBasic(t *testing.T) {
+	c := NewConcurrent[string](true)
+	if !c.Directed() {
+		t.Error("graph should be directed")
+	}
+	c.AddEdge("a", "b", 2)
+	c.AddVertex("c")
+
+	if !c.HasVertex("c") || c.HasVertex("z") {
+		t.Error("HasVertex returned the wrong answer")
+	}
+	if w, ok := c.Weight("a", "b"); !ok || w != 2 {
+		t.Errorf("Weight(a, b) = %v, %v", w, ok)
+	}
+	if _, ok := c.Weight("b", "a"); ok {
+		t.Error("reverse edge should not exist")
+	}
+	n := c.Neighbors("a")
+	n["z"] = 1
+	if _, ok := c.Neighbors("a")["z"]; ok {
+		t.Error("Neighbors should return a copy")
+	}
+	if c.Neighbors("z") != nil {
+		t.Error("Neighbors of a missing vertex should be nil")
+	}
+	if c.Order() != 3 || len(c.Vertices()) != 3 {
+		t.Errorf("Order() = %d, want 3", c.Order())
+	}
+
+	c.RemoveEdge("a", "b")
+	c.RemoveVertex("c")
+	if _, ok := c.Weight("a", "b"); ok || c.HasVertex("c") {
+		t.Error("removals should be applied")
+	}
+}
+
+func TestConcurrentSnapshot(t *testing.T) {
+	c := NewConcurrent[int](false)
+	c.AddEdge(1, 2, 1)
+
+	s1 := c.Snapshot()
+	if s2 := c.Snapshot(); s2 != s1 {
+		t.Error("snapshot should be cached until the next write")
+	}
+
+	c.AddEdge(2, 3, 1)
+	if s1.HasVertex(3) {
+		t.Error("an old snapshot should not see later writes")
+	}
+	s3 := c.Snapshot()
+	if s3 == s1 || !s3.HasVertex(3) {
+		t.Error("a write should invalidate the cached snapshot")
+	}
+
+	c.Update(func(g *Graph[int]) {
+		g.RemoveVertex(1)
+		g.AddEdge(3, 4, 1)
+	})
+	if s := c.Snapshot(); s.HasVertex(1) || !s.HasVertex(4) {
+		t.Errorf("snapshot after Update has vertices %v", s.Vertices())
+	}
+}
+
+func TestConcurrentSnapshotCopyOnWrite(t *testing.T) {
+	c := NewConcurrent[int](false)
+	c.AddEdge(1, 2, 1)
+	c.AddEdge(2, 3, 1)
+	c.AddEdge(4, 5, 1)
+	old := c.Snapshot()
+
+	c.RemoveVertex(1)
+	c.AddEdge(3, 4, 7)
+	if _, ok := old.Neighbors(2)[1]; !ok || !old.HasVertex(1) {
+		t.Error("an old snapshot should keep removed vertices and edges")
+	}
+	if _, ok := old.Neighbors(3)[4]; ok {
+		t.Error("an old snapshot should not see added edges")
+	}
+	s := c.Snapshot()
+	if s.HasVertex(1) || s.Neighbors(4)[3] != 7 || len(s.Neighbors(2)) != 1 {
+		t.Errorf("snapshot after writes is wrong: %v", s.Edges())
+	}
+	if got := s.Vertices(); len(got) != 4 || got[0] != 2 {
+		t.Errorf("Vertices() = %v, want [2 3 4 5]", got)
+	}
+}
+
+func TestConcurrentSnapshotDuringWrite(t *testing.T) {
+	c := NewConcurrent[int](true)
+	c.AddEdge(1, 2, 1)
+	c.Update(func(g *Graph[int]) {
+		g.AddEdge(2, 3, 1)
+		done := make(chan *Graph[int])
+		go func() { done <- c.Snapshot() }()
+		select {
+		case s := <-done:
+			if s.HasVertex(3) {
+				t.Error("a snapshot should not see a write in progress")
+			}
+		case <-time.After(time.Second):
+			t.Fatal("Snapshot blocked while a write was in progress")
+		}
+	})
+	if !c.Snapshot().HasVertex(3) {
+		t.Error("the write should be published when Update returns")
+	}
+}
+
+func TestConcurrentReadersAndWriters(t *testing.T) {
+	c := NewConcurrent[int](true)
+	const writers, edges = 4, 200
+
+	var wg sync.WaitGroup
+	for w := 0; w < writers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < edges; i++ {
+				// Both directions are added in one Update, so a consistent
+				// snapshot always has an even number of arcs.
+				c.Update(func(g *Graph[int]) {
+					g.AddEdge(w*edges+i, w*edges+i+1, 1)
+					g.AddEdge(w*edges+i+1, w*edges+i, 1)
+				})
+			}
+		}()
+	}
+	for r := 0; r < 4; r++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				s := c.Snapshot()
+				arcs := 0
+				for _, v := range s.Vertices() {
+					arcs += len(s.Neighbors(v))
+				}
+				if arcs%2 != 0 {
+					t.Errorf("snapshot saw a partial update: %d arcs", arcs)
+					return
+				}
+				PageRank(s, PageRankOptions[int]{MaxIterations: 5})
+				c.Neighbors(i)
+			}
+		}()
+	}
+	wg.Wait()
+
+	s := c.Snapshot()
+	if got, want := len(s.Edges()), writers*edges*2; got != want {
+		t.Errorf("final graph has %d edges, want %d", got, want)
+	}
+}
+
+func BenchmarkConcurrentSnapshot(b *testing.B) {
+	c := NewConcurrent[int](false)
+	for i := 0; i < 1000; i++ {
+		c.AddEdge(i, (i+1)%1000, 1)
+	}
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			c.Snapshot()
+		}
+	})
+}
@@ -0,0 +1,146 @@
+package graph
+
+// Connectivity tracks the connected components of a graph as it changes.
+// Directed edges are treated as undirected, so components are weakly
+// connected. Additions are applied incrementally with a union-find forest;
+// a removal marks the structure stale and it is rebuilt from the graph on
+// the next query.
+//
+// Like Graph, Connectivity is not safe for concurrent use.
+type Connectivity[T comparable] struct {
+	g      *Graph[T]
+	parent map[T]T
+	size   map[T]int
+	count  int
+	stale  bool
+	cancel func()
+}
+
+// NewConnectivity returns a Connectivity for g that observes its changes
+// until Close is called.
+func NewConnectivity[T comparable](g *Graph[T]) *Connectivity[T] {
+	c := &Connectivity[T]{g: g}
+	c.rebuild()
+	c.cancel = g.Observe(c.update)
+	return c
+}
+
+// Close stops tracking changes to the graph.
+func (c *Connectivity[T]) Close() {
+	c.cancel()
+}
+
+func (c *Connectivity[T]) update(e Event[T]) {
+	if c.stale {
+		return
+	}
+	switch e.Kind {
+	case VertexAdded:
+		c.add(e.Vertex)
+	case EdgeAdded:
+		c.union(e.Edge.From, e.Edge.To)
+	case EdgeRemoved, VertexRemoved:
+		c.stale = true
+	}
+}
+
+func (c *Connectivity[T]) rebuild() {
+	c.parent = make(map[T]T, len(c.g.order))
+	c.size = make(map[T]int, len(c.g.order))
+	c.count = 0
+	for _, v := range c.g.order {
+		c.add(v)
+	}
+	for _, u := range c.g.order {
+		for v := range c.g.adj[u] {
+			c.union(u, v)
+		}
+	}
+	c.stale = false
+}
+
+func (c *Connectivity[T]) add(v T) {
+	c.parent[v] = v
+	c.size[v] = 1
+	c.count++
+}
+
+func (c *Connectivity[T]) find(v T) T {
+	root := v
+	for c.parent[root] != root {
+		root = c.parent[root]
+	}
+	for v != root {
+		v, c.parent[v] = c.parent[v], root
+	}
+	return root
+}
+
+func (c *Connectivity[T]) union(u, v T) {
+	ru, rv := c.find(u), c.find(v)
+	if ru == rv {
+		return
+	}
+	if c.size[ru] < c.size[rv] {
+		ru, rv = rv, ru
+	}
+	c.parent[rv] = ru
+	c.size[ru] += c.size[rv]
+	delete(c.size, rv)
+	c.count--
+}
+
+func (c *Connectivity[T]) fresh() {
+	if c.stale {
+		c.rebuild()
+	}
+}
+
+// Connected reports whether u and v are in the same component. A vertex
+// that is not in the graph is connected to nothing.
+func (c *Connectivity[T]) Connected(u, v T) bool {
+	c.fresh()
+	if _, ok := c.parent[u]; !ok {
+		return false
+	}
+	if _, ok := c.parent[v]; !ok {
+		return false
+	}
+	return c.find(u) == c.find(v)
+}
+
+// Count returns the number of connected components.
+func (c *Connectivity[T]) Count() int {
+	c.fresh()
+	return c.count
+}
+
+// ComponentSize returns the number of vertices in v's component, or 0 if v
+// is not in the graph.
+func (c *Connectivity[T]) ComponentSize(v T) int {
+	c.fresh()
+	if _, ok := c.parent[v]; !ok {
+		return 0
+	}
+	return c.size[c.find(v)]
+}
+
+// Components returns the vertices of each component. Components are
+// ordered by their first vertex in insertion order, and vertices within a
+// component keep that order.
+func (c *Connectivity[T]) Components() [][]T {
+	c.fresh()
+	index := make(map[T]int)
+	var out [][]T
+	for _, v := range c.g.order {
+		r := c.find(v)
+		i, ok := index[r]
+		if !ok {
+			i = len(out)
+			index[r] = i
+			out = append(out, nil)
+		}
+		out[i] = append(out[i], v)
+	}
+	return out
+}
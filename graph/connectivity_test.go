@@ -0,0 +1,93 @@
+package graph
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+func TestConnectivity(t *testing.T) {
+	g := New[string](true)
+	g.AddEdge("a", "b", 1)
+	c := NewConnectivity(g)
+	defer c.Close()
+
+	g.AddEdge("c", "b", 1)
+	g.AddVertex("d")
+	g.AddEdge("e", "f", 1)
+
+	if n := c.Count(); n != 3 {
+		t.Errorf("Count() = %d, want 3", n)
+	}
+	if !c.Connected("a", "c") {
+		t.Error("a and c should be weakly connected through b")
+	}
+	if c.Connected("a", "e") || c.Connected("a", "missing") {
+		t.Error("a should not be connected to e or to a missing vertex")
+	}
+	if n := c.ComponentSize("b"); n != 3 {
+		t.Errorf("ComponentSize(b) = %d, want 3", n)
+	}
+	want := [][]string{{"a", "b", "c"}, {"d"}, {"e", "f"}}
+	if got := c.Components(); !slices.EqualFunc(got, want, slices.Equal) {
+		t.Errorf("Components() = %v, want %v", got, want)
+	}
+
+	g.RemoveEdge("c", "b")
+	if c.Connected("a", "c") || c.Count() != 4 {
+		t.Errorf("after removing c->b: Connected(a, c) = %v, Count() = %d", c.Connected("a", "c"), c.Count())
+	}
+	g.RemoveVertex("b")
+	if n := c.Count(); n != 4 {
+		t.Errorf("after removing b: Count() = %d, want 4", n)
+	}
+	if c.ComponentSize("b") != 0 {
+		t.Error("removed vertex should have no component")
+	}
+
+	c.Close()
+	g.AddEdge("a", "c", 1)
+	if c.Connected("a", "c") {
+		t.Error("closed Connectivity should stop tracking changes")
+	}
+}
+
+func TestConnectivityRandom(t *testing.T) {
+	r := rand.New(rand.NewPCG(9, 9))
+	g := New[int](false)
+	c := NewConnectivity(g)
+
+	for step := 0; step < 2000; step++ {
+		u, v := r.IntN(60), r.IntN(60)
+		switch r.IntN(10) {
+		case 0:
+			g.RemoveVertex(u)
+		case 1, 2:
+			g.RemoveEdge(u, v)
+		default:
+			g.AddEdge(u, v, 1)
+		}
+		if step%50 != 0 {
+			continue
+		}
+		// Compare with a BFS from scratch.
+		components := 0
+		seen := make(map[int]bool)
+		for _, s := range g.Vertices() {
+			if seen[s] {
+				continue
+			}
+			components++
+			BFS(g, s, func(x, _ int) bool {
+				seen[x] = true
+				if !c.Connected(s, x) {
+					t.Fatalf("step %d: %d and %d should be connected", step, s, x)
+				}
+				return true
+			})
+		}
+		if c.Count() != components {
+			t.Fatalf("step %d: Count() = %d, want %d", step, c.Count(), components)
+		}
+	}
+}
@@ -0,0 +1,182 @@
+package graph
+
+import (
+	"math"
+	"slices"
+
+	"github.com/goforces/gollection/queue"
+)
+
+// CSR is an immutable graph in compressed sparse row form. Vertices are
+// numbered 0..Order()-1 and the outgoing edges of vertex i are stored in
+// targets[offsets[i]:offsets[i+1]], sorted by target ID.
+//
+// A CSR is safe for concurrent reads since it is never modified.
+type CSR[T comparable] struct {
+	directed bool
+	vertices []T
+	index    map[T]int
+	offsets  []int
+	targets  []int
+	weights  []float64
+}
+
+// Freeze returns an immutable CSR copy of the graph. Vertex IDs follow the
+// order of g.Vertices(). Later changes to g are not reflected in the result.
+func (g *Graph[T]) Freeze() *CSR[T] {
+	vertices := g.Vertices()
+	index := make(map[T]int, len(vertices))
+	arcs := 0
+	for i, v := range vertices {
+		index[v] = i
+		arcs += len(g.adj[v])
+	}
+
+	c := &CSR[T]{
+		directed: g.directed,
+		vertices: vertices,
+		index:    index,
+		offsets:  make([]int, len(vertices)+1),
+		targets:  make([]int, 0, arcs),
+		weights:  make([]float64, 0, arcs),
+	}
+	for i, v := range vertices {
+		start := len(c.targets)
+		for n := range g.adj[v] {
+			c.targets = append(c.targets, index[n])
+		}
+		row := c.targets[start:]
+		slices.Sort(row)
+		for _, id := range row {
+			c.weights = append(c.weights, g.adj[v][vertices[id]])
+		}
+		c.offsets[i+1] = len(c.targets)
+	}
+	return c
+}
+
+// Directed reports whether edges are one-way.
+func (c *CSR[T]) Directed() bool { return c.directed }
+
+// Order returns the number of vertices.
+func (c *CSR[T]) Order() int { return len(c.vertices) }
+
+// ID returns the integer ID of v.
+func (c *CSR[T]) ID(v T) (int, bool) {
+	id, ok := c.index[v]
+	return id, ok
+}
+
+// Vertex returns the vertex with the given ID. It panics if id is out of range.
+func (c *CSR[T]) Vertex(id int) T { return c.vertices[id] }
+
+// Vertices returns all vertices ordered by ID.
+func (c *CSR[T]) Vertices() []T { return slices.Clone(c.vertices) }
+
+// Adjacent returns the target IDs and weights of the edges leaving id.
+// The returned slices share storage with the CSR and must not be modified.
+func (c *CSR[T]) Adjacent(id int) (targets []int, weights []float64) {
+	lo, hi := c.offsets[id], c.offsets[id+1]
+	return c.targets[lo:hi], c.weights[lo:hi]
+}
+
+// Weight returns the weight of the edge u->v.
+func (c *CSR[T]) Weight(u, v T) (float64, bool) {
+	from, ok := c.index[u]
+	if !ok {
+		return 0, false
+	}
+	to, ok := c.index[v]
+	if !ok {
+		return 0, false
+	}
+	targets, weights := c.Adjacent(from)
+	i, found := slices.BinarySearch(targets, to)
+	if !found {
+		return 0, false
+	}
+	return weights[i], true
+}
+
+// HasEdge reports whether the edge u->v exists.
+func (c *CSR[T]) HasEdge(u, v T) bool {
+	_, ok := c.Weight(u, v)
+	return ok
+}
+
+// Neighbors returns a newly allocated neighbor-weight map for v, or nil if
+// v is not in the graph.
+func (c *CSR[T]) Neighbors(v T) map[T]float64 {
+	id, ok := c.index[v]
+	if !ok {
+		return nil
+	}
+	targets, weights := c.Adjacent(id)
+	out := make(map[T]float64, len(targets))
+	for i, t := range targets {
+		out[c.vertices[t]] = weights[i]
+	}
+	return out
+}
+
+// BFS visits vertices reachable from src in breadth-first order, calling
+// visit with each vertex ID and its depth. Traversal stops early when visit
+// returns false.
+func (c *CSR[T]) BFS(src int, visit func(id, depth int) bool) {
+	depth := make([]int, len(c.vertices))
+	for i := range depth {
+		depth[i] = -1
+	}
+	depth[src] = 0
+	frontier := []int{src}
+	for head := 0; head < len(frontier); head++ {
+		u := frontier[head]
+		if !visit(u, depth[u]) {
+			return
+		}
+		targets, _ := c.Adjacent(u)
+		for _, v := range targets {
+			if depth[v] < 0 {
+				depth[v] = depth[u] + 1
+				frontier = append(frontier, v)
+			}
+		}
+	}
+}
+
+// ShortestPaths runs Dijkstra's algorithm from src. dist[i] is the cost of
+// the cheapest path to vertex i (+Inf if unreachable) and prev[i] is the
+// previous vertex on that path (-1 for src and unreachable vertices).
+// Edge weights must be non-negative.
+func (c *CSR[T]) ShortestPaths(src int) (dist []float64, prev []int) {
+	type item struct {
+		id   int
+		dist float64
+	}
+
+	dist = make([]float64, len(c.vertices))
+	prev = make([]int, len(c.vertices))
+	for i := range dist {
+		dist[i] = math.Inf(1)
+		prev[i] = -1
+	}
+	dist[src] = 0
+
+	pq := queue.NewPriorityQueue(func(a, b item) bool { return a.dist < b.dist })
+	pq.Push(item{src, 0})
+	for !pq.IsEmpty() {
+		cur, _ := pq.Pop()
+		if cur.dist > dist[cur.id] {
+			continue // stale entry
+		}
+		targets, weights := c.Adjacent(cur.id)
+		for i, v := range targets {
+			if d := cur.dist + weights[i]; d < dist[v] {
+				dist[v] = d
+				prev[v] = cur.id
+				pq.Push(item{v, d})
+			}
+		}
+	}
+	return dist, prev
+}
@@ -0,0 +1,187 @@
+package graph
+
+import (
+	"math"
+	"testing"
+)
+
+func TestFreeze(t *testing.T) {
+	g := New[string](true)
+	g.AddEdge("A", "B", 1)
+	g.AddEdge("A", "C", 4)
+	g.AddEdge("B", "C", 2)
+	g.AddVertex("D")
+
+	c := g.Freeze()
+
+	if c.Order() != 4 {
+		t.Fatalf("order = %d, want 4", c.Order())
+	}
+	if !c.Directed() {
+		t.Error("frozen graph should keep the directed flag")
+	}
+	for _, v := range g.Vertices() {
+		id, ok := c.ID(v)
+		if !ok {
+			t.Fatalf("vertex %q missing from CSR", v)
+		}
+		if c.Vertex(id) != v {
+			t.Errorf("Vertex(ID(%q)) = %q", v, c.Vertex(id))
+		}
+		want := g.Neighbors(v)
+		got := c.Neighbors(v)
+		if len(got) != len(want) {
+			t.Errorf("Neighbors(%q) has %d entries, want %d", v, len(got), len(want))
+		}
+		for n, w := range want {
+			if got[n] != w {
+				t.Errorf("edge %q->%q = %v, want %v", v, n, got[n], w)
+			}
+		}
+	}
+	if _, ok := c.ID("Z"); ok {
+		t.Error("unknown vertex should not have an ID")
+	}
+	if c.Neighbors("Z") != nil {
+		t.Error("Neighbors of unknown vertex should be nil")
+	}
+}
+
+func TestFreezeIndependence(t *testing.T) {
+	g := New[int](false)
+	g.AddEdge(1, 2, 1)
+	c := g.Freeze()
+
+	g.AddEdge(2, 3, 1)
+	g.RemoveEdge(1, 2)
+
+	if !c.HasEdge(1, 2) || !c.HasEdge(2, 1) {
+		t.Error("frozen graph should keep edge 1-2")
+	}
+	if _, ok := c.ID(3); ok {
+		t.Error("frozen graph should not see later vertices")
+	}
+}
+
+func TestCSRWeight(t *testing.T) {
+	g := New[int](true)
+	for i := 1; i < 10; i++ {
+		g.AddEdge(0, i, float64(i))
+	}
+	c := g.Freeze()
+
+	for i := 1; i < 10; i++ {
+		if w, ok := c.Weight(0, i); !ok || w != float64(i) {
+			t.Errorf("Weight(0, %d) = %v, %v", i, w, ok)
+		}
+	}
+	if c.HasEdge(1, 0) {
+		t.Error("reverse edge should not exist")
+	}
+	if c.HasEdge(0, 42) || c.HasEdge(42, 0) {
+		t.Error("edges to unknown vertices should not exist")
+	}
+
+	id, _ := c.ID(0)
+	targets, weights := c.Adjacent(id)
+	if len(targets) != 9 || len(weights) != 9 {
+		t.Fatalf("Adjacent returned %d targets, %d weights, want 9", len(targets), len(weights))
+	}
+	for i := 1; i < len(targets); i++ {
+		if targets[i-1] >= targets[i] {
+			t.Error("Adjacent targets should be sorted by ID")
+		}
+	}
+}
+
+func TestCSRBFS(t *testing.T) {
+	g := New[int](true)
+	g.AddEdge(0, 1, 1)
+	g.AddEdge(0, 2, 1)
+	g.AddEdge(1, 3, 1)
+	g.AddEdge(2, 3, 1)
+	g.AddEdge(3, 4, 1)
+	g.AddVertex(5)
+	c := g.Freeze()
+
+	src, _ := c.ID(0)
+	depths := make(map[int]int)
+	c.BFS(src, func(id, depth int) bool {
+		depths[c.Vertex(id)] = depth
+		return true
+	})
+
+	want := map[int]int{0: 0, 1: 1, 2: 1, 3: 2, 4: 3}
+	if len(depths) != len(want) {
+		t.Fatalf("visited %d vertices, want %d", len(depths), len(want))
+	}
+	for v, d := range want {
+		if depths[v] != d {
+			t.Errorf("depth(%d) = %d, want %d", v, depths[v], d)
+		}
+	}
+
+	visited := 0
+	c.BFS(src, func(id, depth int) bool {
+		visited++
+		return visited < 2
+	})
+	if visited != 2 {
+		t.Errorf("BFS should stop when visit returns false, visited %d", visited)
+	}
+}
+
+func TestCSRShortestPaths(t *testing.T) {
+	g := New[string](true)
+	g.AddEdge("A", "B", 1)
+	g.AddEdge("A", "C", 4)
+	g.AddEdge("B", "C", 2)
+	g.AddEdge("C", "D", 1)
+	g.AddVertex("E")
+	c := g.Freeze()
+
+	src, _ := c.ID("A")
+	dist, prev := c.ShortestPaths(src)
+
+	want := map[string]float64{"A": 0, "B": 1, "C": 3, "D": 4}
+	for v, d := range want {
+		id, _ := c.ID(v)
+		if dist[id] != d {
+			t.Errorf("dist(%s) = %v, want %v", v, dist[id], d)
+		}
+	}
+
+	e, _ := c.ID("E")
+	if !math.IsInf(dist[e], 1) || prev[e] != -1 {
+		t.Error("unreachable vertex should have +Inf distance and no predecessor")
+	}
+
+	// Walk back from D: D <- C <- B <- A
+	var path []string
+	for id, _ := c.ID("D"); id != -1; id = prev[id] {
+		path = append([]string{c.Vertex(id)}, path...)
+	}
+	wantPath := []string{"A", "B", "C", "D"}
+	if len(path) != len(wantPath) {
+		t.Fatalf("path = %v, want %v", path, wantPath)
+	}
+	for i := range path {
+		if path[i] != wantPath[i] {
+			t.Errorf("path = %v, want %v", path, wantPath)
+			break
+		}
+	}
+}
+
+func BenchmarkCSRShortestPaths(b *testing.B) {
+	g := New[int](true)
+	for i := 0; i < 1000; i++ {
+		g.AddEdge(i, (i+1)%1000, 1)
+		g.AddEdge(i, (i*7)%1000, 3)
+	}
+	c := g.Freeze()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		c.ShortestPaths(0)
+	}
+}
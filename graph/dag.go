@@ -0,0 +1,172 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+
+	"github.com/goforces/gollection/queue"
+)
+
+// ErrCycle is returned when a graph that must be acyclic contains a cycle.
+var ErrCycle = errors.New("graph: cycle")
+
+// ErrDependencyFailed is wrapped by the error recorded for a vertex that was
+// not run because one of the vertices it depends on failed.
+var ErrDependencyFailed = errors.New("graph: dependency failed")
+
+// TopologicalSort returns the vertices of a directed graph ordered so that
+// every edge u->v has u before v. Among vertices that could come next, the
+// one added to the graph first is chosen, so the order is deterministic.
+// It returns ErrCycle if g has a cycle; in an undirected graph every edge
+// counts as one.
+func TopologicalSort[T comparable](g *Graph[T]) ([]T, error) {
+	index := make(map[T]int, len(g.order))
+	for i, v := range g.order {
+		index[v] = i
+	}
+	indegree := make([]int, len(g.order))
+	for _, u := range g.order {
+		for v := range g.adj[u] {
+			indegree[index[v]]++
+		}
+	}
+
+	ready := queue.NewPriorityQueue(func(a, b int) bool { return a < b })
+	for i, d := range indegree {
+		if d == 0 {
+			ready.Push(i)
+		}
+	}
+	out := make([]T, 0, len(g.order))
+	for !ready.IsEmpty() {
+		i, _ := ready.Pop()
+		u := g.order[i]
+		out = append(out, u)
+		for v := range g.adj[u] {
+			j := index[v]
+			if indegree[j]--; indegree[j] == 0 {
+				ready.Push(j)
+			}
+		}
+	}
+	if len(out) < len(g.order) {
+		return nil, ErrCycle
+	}
+	return out, nil
+}
+
+// Result is the outcome of the task run for one vertex by ExecuteDAG.
+type Result[R any] struct {
+	Value R
+	Err   error
+}
+
+// ExecuteDAG runs task once for every vertex of the directed acyclic graph
+// g, with at most parallelism tasks running at a time (GOMAXPROCS if
+// parallelism < 1). An edge u->v means v depends on u: v's task starts only
+// after u's has succeeded. When a task fails, the vertices that depend on it
+// directly or indirectly are not run, and their Err wraps both
+// ErrDependencyFailed and the original error. Independent vertices still run.
+//
+// Tasks receive ctx. Once ctx is done no new tasks are started and the
+// vertices that never ran record ctx.Err().
+//
+// The returned map holds a Result for every vertex. The error is ErrCycle,
+// with a nil map, if g is not acyclic; otherwise it joins the errors of the
+// tasks that failed, in topological order, and ctx.Err() if ctx was done.
+func ExecuteDAG[T comparable, R any](ctx context.Context, g *Graph[T], parallelism int, task func(ctx context.Context, v T) (R, error)) (map[T]Result[R], error) {
+	order, err := TopologicalSort(g)
+	if err != nil {
+		return nil, err
+	}
+	if parallelism < 1 {
+		parallelism = runtime.GOMAXPROCS(0)
+	}
+
+	type done struct {
+		v   T
+		res Result[R]
+	}
+	// ready holds positions in order, so runnable tasks start in
+	// topological order.
+	pos := make(map[T]int, len(order))
+	for i, v := range order {
+		pos[v] = i
+	}
+	pending := make(map[T]int, len(order))
+	for _, u := range order {
+		for v := range g.adj[u] {
+			pending[v]++
+		}
+	}
+	ready := queue.NewPriorityQueue(func(a, b int) bool { return a < b })
+	for i, v := range order {
+		if pending[v] == 0 {
+			ready.Push(i)
+		}
+	}
+
+	results := make(map[T]Result[R], len(order))
+	failed := make(map[T]bool)
+	// skip records the failure of every vertex reachable from v.
+	skip := func(v T, cause error) {
+		stack := []T{v}
+		for len(stack) > 0 {
+			u := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			for s := range g.adj[u] {
+				if _, ok := results[s]; !ok {
+					results[s] = Result[R]{Err: fmt.Errorf("%w: %v: %w", ErrDependencyFailed, v, cause)}
+					stack = append(stack, s)
+				}
+			}
+		}
+	}
+
+	finished := make(chan done)
+	running := 0
+	for {
+		for running < parallelism && !ready.IsEmpty() && ctx.Err() == nil {
+			i, _ := ready.Pop()
+			v := order[i]
+			running++
+			go func() {
+				val, err := task(ctx, v)
+				finished <- done{v, Result[R]{Value: val, Err: err}}
+			}()
+		}
+		if running == 0 {
+			break
+		}
+		d := <-finished
+		running--
+		results[d.v] = d.res
+		if d.res.Err != nil {
+			failed[d.v] = true
+			skip(d.v, d.res.Err)
+			continue
+		}
+		for s := range g.adj[d.v] {
+			if pending[s]--; pending[s] == 0 {
+				if _, skipped := results[s]; !skipped {
+					ready.Push(pos[s])
+				}
+			}
+		}
+	}
+
+	var errs []error
+	for _, v := range order {
+		if r, ok := results[v]; !ok {
+			results[v] = Result[R]{Err: ctx.Err()}
+		} else if failed[v] {
+			errs = append(errs, fmt.Errorf("%v: %w", v, r.Err))
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		errs = append(errs, err)
+	}
+	return results, errors.Join(errs...)
+}
@@ -0,0 +1,191 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestTopologicalSort(t *testing.T) {
+	g := New[string](true)
+	g.AddEdge("shirt", "tie", 1)
+	g.AddEdge("tie", "jacket", 1)
+	g.AddEdge("trousers", "shoes", 1)
+	g.AddEdge("trousers", "belt", 1)
+	g.AddEdge("belt", "jacket", 1)
+	g.AddEdge("socks", "shoes", 1)
+
+	got, err := TopologicalSort(g)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"shirt", "tie", "trousers", "belt", "jacket", "socks", "shoes"}
+	if !slices.Equal(got, want) {
+		t.Errorf("order = %v, want %v", got, want)
+	}
+
+	empty, err := TopologicalSort(New[int](true))
+	if err != nil || len(empty) != 0 {
+		t.Errorf("empty graph: %v, %v", empty, err)
+	}
+}
+
+func TestTopologicalSortCycle(t *testing.T) {
+	g := New[int](true)
+	g.AddEdge(1, 2, 1)
+	g.AddEdge(2, 3, 1)
+	g.AddEdge(3, 1, 1)
+	g.AddEdge(0, 1, 1)
+	if _, err := TopologicalSort(g); !errors.Is(err, ErrCycle) {
+		t.Errorf("err = %v, want ErrCycle", err)
+	}
+
+	loop := New[int](true)
+	loop.AddEdge(1, 1, 1)
+	if _, err := TopologicalSort(loop); !errors.Is(err, ErrCycle) {
+		t.Errorf("self-loop: err = %v, want ErrCycle", err)
+	}
+
+	if _, err := TopologicalSort(PathGraph(2, false)); !errors.Is(err, ErrCycle) {
+		t.Errorf("undirected edge: err = %v, want ErrCycle", err)
+	}
+}
+
+func TestExecuteDAG(t *testing.T) {
+	g := RandomDAG(40, 0.15, rand.New(rand.NewPCG(1, 2)))
+	var mu sync.Mutex
+	finished := make(map[int]bool)
+
+	results, err := ExecuteDAG(context.Background(), g, 4, func(ctx context.Context, v int) (int, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		for p := range g.Predecessors(v) {
+			if !finished[p] {
+				t.Errorf("%d started before its dependency %d finished", v, p)
+			}
+		}
+		finished[v] = true
+		return v * v, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 40 {
+		t.Fatalf("got %d results, want 40", len(results))
+	}
+	for v, r := range results {
+		if r.Err != nil || r.Value != v*v {
+			t.Errorf("result(%d) = %+v", v, r)
+		}
+	}
+}
+
+func TestExecuteDAGParallelism(t *testing.T) {
+	// Twenty independent tasks, at most three at a time.
+	g := New[int](true)
+	for i := 0; i < 20; i++ {
+		g.AddVertex(i)
+	}
+	var cur, peak atomic.Int32
+	_, err := ExecuteDAG(context.Background(), g, 3, func(ctx context.Context, v int) (struct{}, error) {
+		n := cur.Add(1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond)
+		cur.Add(-1)
+		return struct{}{}, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p := peak.Load(); p > 3 {
+		t.Errorf("peak concurrency = %d, want at most 3", p)
+	}
+}
+
+func TestExecuteDAGFailure(t *testing.T) {
+	// build -> test -> deploy, build -> docs, lint -> deploy
+	g := New[string](true)
+	g.AddEdge("build", "test", 1)
+	g.AddEdge("test", "deploy", 1)
+	g.AddEdge("build", "docs", 1)
+	g.AddEdge("lint", "deploy", 1)
+	g.AddVertex("fmt")
+
+	errTest := errors.New("tests failed")
+	var ran sync.Map
+	results, err := ExecuteDAG(context.Background(), g, 2, func(ctx context.Context, v string) (string, error) {
+		ran.Store(v, true)
+		if v == "test" {
+			return "", errTest
+		}
+		return v + " ok", nil
+	})
+
+	if !errors.Is(err, errTest) {
+		t.Errorf("err = %v, want it to wrap the task error", err)
+	}
+	if errors.Is(err, ErrDependencyFailed) {
+		t.Error("skipped vertices should not be reported in the returned error")
+	}
+	if _, ok := ran.Load("deploy"); ok {
+		t.Error("deploy should not run after test failed")
+	}
+	dep := results["deploy"].Err
+	if !errors.Is(dep, ErrDependencyFailed) || !errors.Is(dep, errTest) {
+		t.Errorf("deploy error = %v, want dependency failure wrapping the test error", dep)
+	}
+	for _, v := range []string{"build", "docs", "lint", "fmt"} {
+		if r := results[v]; r.Err != nil || r.Value != v+" ok" {
+			t.Errorf("%s = %+v, independent tasks should still succeed", v, r)
+		}
+	}
+	if results["test"].Err != errTest {
+		t.Errorf("test error = %v, want %v", results["test"].Err, errTest)
+	}
+}
+
+func TestExecuteDAGCancel(t *testing.T) {
+	g := PathGraph(5, true)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	results, err := ExecuteDAG(ctx, g, 1, func(ctx context.Context, v int) (int, error) {
+		if v == 1 {
+			cancel()
+		}
+		return v, nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("err = %v, want context.Canceled", err)
+	}
+	for v := 0; v <= 1; v++ {
+		if results[v].Err != nil {
+			t.Errorf("%d ran before cancellation, got %v", v, results[v].Err)
+		}
+	}
+	for v := 2; v < 5; v++ {
+		if !errors.Is(results[v].Err, context.Canceled) {
+			t.Errorf("%d error = %v, want context.Canceled", v, results[v].Err)
+		}
+	}
+}
+
+func TestExecuteDAGCycle(t *testing.T) {
+	called := false
+	results, err := ExecuteDAG(context.Background(), CycleGraph(3, true), 2, func(ctx context.Context, v int) (int, error) {
+		called = true
+		return 0, nil
+	})
+	if !errors.Is(err, ErrCycle) || results != nil || called {
+		t.Errorf("cyclic graph: results %v, err %v, called %v", results, err, called)
+	}
+}
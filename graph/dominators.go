@@ -0,0 +1,201 @@
+package graph
+
+import "slices"
+
+// DominatorTree holds the dominance relation of a directed flow graph for a
+// given entry vertex. Vertex d dominates v if every path from the entry to v
+// passes through d. Only vertices reachable from the entry are included.
+type DominatorTree[T comparable] struct {
+	entry     T
+	idom      map[T]T
+	children  map[T][]T
+	frontier  map[T][]T
+	pre, post map[T]int // DFS numbering of the tree, for Dominates
+}
+
+// Dominators computes the dominator tree of g rooted at entry using the
+// iterative algorithm of Cooper, Harvey and Kennedy, along with the
+// dominance frontier of every reachable vertex.
+func Dominators[T comparable](g *Graph[T], entry T) *DominatorTree[T] {
+	byRPO := reversePostorder(g, entry)
+	pred := predecessors[T](g)
+	rpo := make(map[T]int, len(byRPO))
+	for i, v := range byRPO {
+		rpo[v] = i
+	}
+
+	// idom[i] is the RPO number of the immediate dominator of byRPO[i].
+	idom := make([]int, len(byRPO))
+	for i := range idom {
+		idom[i] = -1
+	}
+	intersect := func(a, b int) int {
+		for a != b {
+			for a > b {
+				a = idom[a]
+			}
+			for b > a {
+				b = idom[b]
+			}
+		}
+		return a
+	}
+	if len(byRPO) > 0 {
+		idom[0] = 0
+	}
+	for changed := true; changed; {
+		changed = false
+		for i := 1; i < len(byRPO); i++ {
+			newIdom := -1
+			for p := range pred(byRPO[i]) {
+				j, ok := rpo[p]
+				if !ok || idom[j] < 0 {
+					continue
+				}
+				if newIdom < 0 {
+					newIdom = j
+				} else {
+					newIdom = intersect(j, newIdom)
+				}
+			}
+			if idom[i] != newIdom {
+				idom[i] = newIdom
+				changed = true
+			}
+		}
+	}
+
+	dt := &DominatorTree[T]{
+		entry:    entry,
+		idom:     make(map[T]T, len(byRPO)),
+		children: make(map[T][]T),
+		frontier: make(map[T][]T),
+		pre:      make(map[T]int, len(byRPO)),
+		post:     make(map[T]int, len(byRPO)),
+	}
+	for i := 1; i < len(byRPO); i++ {
+		parent := byRPO[idom[i]]
+		dt.idom[byRPO[i]] = parent
+		dt.children[parent] = append(dt.children[parent], byRPO[i])
+	}
+
+	// Dominance frontiers: from each predecessor of a join point, walk up
+	// the tree until reaching the join point's immediate dominator.
+	for i, b := range byRPO {
+		var preds []int
+		for p := range pred(b) {
+			if j, ok := rpo[p]; ok {
+				preds = append(preds, j)
+			}
+		}
+		if len(preds) < 2 && i != 0 {
+			continue
+		}
+		for _, runner := range preds {
+			for {
+				if i != 0 && runner == idom[i] {
+					break
+				}
+				r := byRPO[runner]
+				if !slices.Contains(dt.frontier[r], b) {
+					dt.frontier[r] = append(dt.frontier[r], b)
+				}
+				if runner == 0 {
+					break // the entry has no immediate dominator
+				}
+				runner = idom[runner]
+			}
+		}
+	}
+
+	if len(byRPO) > 0 {
+		clock := 0
+		var walk func(v T)
+		walk = func(v T) {
+			dt.pre[v] = clock
+			clock++
+			for _, c := range dt.children[v] {
+				walk(c)
+			}
+			dt.post[v] = clock
+			clock++
+		}
+		walk(entry)
+	}
+	return dt
+}
+
+// reversePostorder returns the vertices reachable from entry in reverse
+// postorder of a depth-first search.
+func reversePostorder[T comparable](g *Graph[T], entry T) []T {
+	if _, ok := g.adj[entry]; !ok {
+		return nil
+	}
+	type frame struct {
+		v    T
+		next []T
+	}
+	successors := func(v T) []T {
+		out := make([]T, 0, len(g.adj[v]))
+		for n := range g.adj[v] {
+			out = append(out, n)
+		}
+		return out
+	}
+
+	var order []T
+	visited := map[T]bool{entry: true}
+	stack := []frame{{entry, successors(entry)}}
+	for len(stack) > 0 {
+		top := &stack[len(stack)-1]
+		if len(top.next) == 0 {
+			order = append(order, top.v)
+			stack = stack[:len(stack)-1]
+			continue
+		}
+		n := top.next[0]
+		top.next = top.next[1:]
+		if !visited[n] {
+			visited[n] = true
+			stack = append(stack, frame{n, successors(n)})
+		}
+	}
+	slices.Reverse(order)
+	return order
+}
+
+// Entry returns the vertex the tree is rooted at.
+func (dt *DominatorTree[T]) Entry() T { return dt.entry }
+
+// Contains reports whether v is reachable from the entry.
+func (dt *DominatorTree[T]) Contains(v T) bool {
+	_, ok := dt.pre[v]
+	return ok
+}
+
+// Idom returns the immediate dominator of v. The boolean is false for the
+// entry and for vertices not reachable from it.
+func (dt *DominatorTree[T]) Idom(v T) (T, bool) {
+	d, ok := dt.idom[v]
+	return d, ok
+}
+
+// Children returns the vertices immediately dominated by v.
+func (dt *DominatorTree[T]) Children(v T) []T {
+	return slices.Clone(dt.children[v])
+}
+
+// Dominates reports whether a dominates b. Every reachable vertex dominates
+// itself.
+func (dt *DominatorTree[T]) Dominates(a, b T) bool {
+	preA, okA := dt.pre[a]
+	preB, okB := dt.pre[b]
+	return okA && okB && preA <= preB && dt.post[b] <= dt.post[a]
+}
+
+// Frontier returns the dominance frontier of v: the vertices where v's
+// dominance ends, i.e. those with a predecessor dominated by v that are not
+// themselves strictly dominated by v.
+func (dt *DominatorTree[T]) Frontier(v T) []T {
+	return slices.Clone(dt.frontier[v])
+}
@@ -0,0 +1,152 @@
+package graph
+
+import (
+	"slices"
+	"testing"
+)
+
+// loopCFG is a flow graph with a diamond inside a loop:
+//
+//	1 -> 2 -> {3, 4} -> 5 -> 6 -> 7, with a back edge 6 -> 2.
+//
+// Vertex 8 is unreachable and jumps into the middle of the loop.
+func loopCFG() *Graph[int] {
+	g := New[int](true)
+	g.AddEdge(1, 2, 1)
+	g.AddEdge(2, 3, 1)
+	g.AddEdge(2, 4, 1)
+	g.AddEdge(3, 5, 1)
+	g.AddEdge(4, 5, 1)
+	g.AddEdge(5, 6, 1)
+	g.AddEdge(6, 2, 1)
+	g.AddEdge(6, 7, 1)
+	g.AddEdge(8, 5, 1)
+	return g
+}
+
+func sortedInts(s []int) []int {
+	slices.Sort(s)
+	return s
+}
+
+func TestDominatorsIdom(t *testing.T) {
+	dt := Dominators(loopCFG(), 1)
+
+	want := map[int]int{2: 1, 3: 2, 4: 2, 5: 2, 6: 5, 7: 6}
+	for v, d := range want {
+		got, ok := dt.Idom(v)
+		if !ok || got != d {
+			t.Errorf("Idom(%d) = %d, %v, want %d", v, got, ok, d)
+		}
+	}
+	if _, ok := dt.Idom(1); ok {
+		t.Error("entry should have no immediate dominator")
+	}
+	if _, ok := dt.Idom(8); ok {
+		t.Error("unreachable vertex should have no immediate dominator")
+	}
+	if dt.Contains(8) || !dt.Contains(7) {
+		t.Error("Contains should report reachability from the entry")
+	}
+	if dt.Entry() != 1 {
+		t.Errorf("Entry() = %d, want 1", dt.Entry())
+	}
+}
+
+func TestDominatorsChildren(t *testing.T) {
+	dt := Dominators(loopCFG(), 1)
+
+	if got := sortedInts(dt.Children(2)); !slices.Equal(got, []int{3, 4, 5}) {
+		t.Errorf("Children(2) = %v, want [3 4 5]", got)
+	}
+	if got := dt.Children(7); len(got) != 0 {
+		t.Errorf("Children(7) = %v, want none", got)
+	}
+}
+
+func TestDominates(t *testing.T) {
+	dt := Dominators(loopCFG(), 1)
+
+	cases := []struct {
+		a, b int
+		want bool
+	}{
+		{1, 7, true},
+		{2, 6, true},
+		{5, 7, true},
+		{3, 5, false},
+		{4, 5, false},
+		{6, 5, false},
+		{5, 5, true},
+		{8, 5, false},
+		{5, 8, false},
+	}
+	for _, c := range cases {
+		if got := dt.Dominates(c.a, c.b); got != c.want {
+			t.Errorf("Dominates(%d, %d) = %v, want %v", c.a, c.b, got, c.want)
+		}
+	}
+}
+
+func TestDominanceFrontier(t *testing.T) {
+	dt := Dominators(loopCFG(), 1)
+
+	want := map[int][]int{
+		1: nil,
+		2: {2},
+		3: {5},
+		4: {5},
+		5: {2},
+		6: {2},
+		7: nil,
+	}
+	for v, f := range want {
+		if got := sortedInts(dt.Frontier(v)); !slices.Equal(got, f) {
+			t.Errorf("Frontier(%d) = %v, want %v", v, got, f)
+		}
+	}
+}
+
+func TestDominatorsLoopToEntry(t *testing.T) {
+	g := New[string](true)
+	g.AddEdge("entry", "body", 1)
+	g.AddEdge("body", "entry", 1)
+
+	dt := Dominators(g, "entry")
+	if d, ok := dt.Idom("body"); !ok || d != "entry" {
+		t.Errorf("Idom(body) = %q, %v", d, ok)
+	}
+	if got := dt.Frontier("body"); !slices.Equal(got, []string{"entry"}) {
+		t.Errorf("Frontier(body) = %v, want [entry]", got)
+	}
+	if got := dt.Frontier("entry"); !slices.Equal(got, []string{"entry"}) {
+		t.Errorf("Frontier(entry) = %v, want [entry]", got)
+	}
+}
+
+func TestDominatorsMissingEntry(t *testing.T) {
+	dt := Dominators(loopCFG(), 99)
+	if dt.Contains(99) || dt.Contains(1) {
+		t.Error("tree for a missing entry should be empty")
+	}
+	if dt.Dominates(99, 99) {
+		t.Error("missing entry should not dominate anything")
+	}
+}
+
+func BenchmarkDominators(b *testing.B) {
+	g := New[int](true)
+	for i := 0; i < 2000; i++ {
+		g.AddEdge(i, i+1, 1)
+		if i%10 == 9 {
+			g.AddEdge(i, i-8, 1)
+		}
+		if i%3 == 0 {
+			g.AddEdge(i, i+2, 1)
+		}
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Dominators(g, 0)
+	}
+}
@@ -0,0 +1,121 @@
+package graph
+
+import "slices"
+
+// EulerianCircuit returns a closed walk that uses every edge exactly once,
+// starting and ending at the same vertex, built with Hierholzer's algorithm.
+// The boolean is false if g has no edges or no such circuit exists.
+func EulerianCircuit[T comparable](g *Graph[T]) ([]T, bool) {
+	return euler(g, true)
+}
+
+// EulerianPath returns a walk that uses every edge exactly once, built with
+// Hierholzer's algorithm. If g has an Eulerian circuit the walk is closed.
+// The boolean is false if g has no edges or no such path exists.
+func EulerianPath[T comparable](g *Graph[T]) ([]T, bool) {
+	return euler(g, false)
+}
+
+func euler[T comparable](g *Graph[T], closed bool) ([]T, bool) {
+	type half struct{ edge, to int }
+
+	vertices := g.Vertices()
+	index := make(map[T]int, len(vertices))
+	for i, v := range vertices {
+		index[v] = i
+	}
+
+	// Number the edges, listing each undirected edge once.
+	adj := make([][]half, len(vertices))
+	out := make([]int, len(vertices))
+	in := make([]int, len(vertices))
+	edges := 0
+	for i, u := range vertices {
+		var targets []int
+		for v := range g.adj[u] {
+			targets = append(targets, index[v])
+		}
+		slices.Sort(targets)
+		for _, j := range targets {
+			if !g.directed && j < i {
+				continue
+			}
+			adj[i] = append(adj[i], half{edges, j})
+			out[i]++
+			in[j]++
+			if !g.directed && i != j {
+				adj[j] = append(adj[j], half{edges, i})
+			}
+			edges++
+		}
+	}
+	if edges == 0 {
+		return nil, false
+	}
+
+	// Pick the start vertex from the degree conditions.
+	start := -1
+	odd := 0
+	for i := range vertices {
+		if g.directed {
+			switch out[i] - in[i] {
+			case 0:
+			case 1:
+				if start >= 0 && out[start]-in[start] == 1 {
+					return nil, false
+				}
+				start = i
+				odd++
+			case -1:
+				odd++
+			default:
+				return nil, false
+			}
+		} else if (out[i]+in[i])%2 == 1 {
+			odd++
+			if start < 0 {
+				start = i
+			}
+		}
+	}
+	if odd != 0 && (closed || odd != 2) {
+		return nil, false
+	}
+	if start < 0 {
+		for i := range vertices {
+			if len(adj[i]) > 0 {
+				start = i
+				break
+			}
+		}
+	}
+
+	used := make([]bool, edges)
+	next := make([]int, len(vertices)) // position in adj of the next unchecked edge
+	stack := []int{start}
+	var walk []int
+	for len(stack) > 0 {
+		u := stack[len(stack)-1]
+		for next[u] < len(adj[u]) && used[adj[u][next[u]].edge] {
+			next[u]++
+		}
+		if next[u] == len(adj[u]) {
+			walk = append(walk, u)
+			stack = stack[:len(stack)-1]
+			continue
+		}
+		h := adj[u][next[u]]
+		used[h.edge] = true
+		stack = append(stack, h.to)
+	}
+	if len(walk) != edges+1 {
+		return nil, false // edges are spread over several components
+	}
+
+	slices.Reverse(walk)
+	path := make([]T, len(walk))
+	for i, id := range walk {
+		path[i] = vertices[id]
+	}
+	return path, true
+}
@@ -0,0 +1,161 @@
+package graph
+
+import "testing"
+
+// checkEulerWalk verifies that walk uses every edge of g exactly once.
+func checkEulerWalk[T comparable](t *testing.T, g *Graph[T], walk []T) {
+	t.Helper()
+	remaining := make(map[[2]T]int)
+	edges := 0
+	for _, u := range g.Vertices() {
+		for v := range g.Neighbors(u) {
+			remaining[[2]T{u, v}]++
+			edges++
+		}
+	}
+	if !g.directed {
+		// Undirected edges are stored in both directions, self-loops once.
+		edges = 0
+		for k := range remaining {
+			if k[0] == k[1] {
+				edges += 2
+			} else {
+				edges++
+			}
+		}
+		edges /= 2
+	}
+	if len(walk) != edges+1 {
+		t.Fatalf("walk %v has %d steps, want %d", walk, len(walk)-1, edges)
+	}
+	for i := 1; i < len(walk); i++ {
+		u, v := walk[i-1], walk[i]
+		if remaining[[2]T{u, v}] == 0 {
+			t.Fatalf("walk %v reuses or invents edge %v->%v", walk, u, v)
+		}
+		remaining[[2]T{u, v}]--
+		if !g.directed && u != v {
+			remaining[[2]T{v, u}]--
+		}
+	}
+}
+
+func TestEulerianCircuitDirected(t *testing.T) {
+	g := New[int](true)
+	g.AddEdge(0, 1, 1)
+	g.AddEdge(1, 2, 1)
+	g.AddEdge(2, 0, 1)
+	g.AddEdge(0, 3, 1)
+	g.AddEdge(3, 4, 1)
+	g.AddEdge(4, 0, 1)
+
+	walk, ok := EulerianCircuit(g)
+	if !ok {
+		t.Fatal("graph should have an Eulerian circuit")
+	}
+	checkEulerWalk(t, g, walk)
+	if walk[0] != walk[len(walk)-1] {
+		t.Errorf("circuit %v should be closed", walk)
+	}
+}
+
+func TestEulerianPathUndirected(t *testing.T) {
+	// The house: a square with a roof, odd vertices at the bottom corners.
+	g := New[string](false)
+	g.AddEdge("bl", "br", 1)
+	g.AddEdge("br", "tr", 1)
+	g.AddEdge("tr", "tl", 1)
+	g.AddEdge("tl", "bl", 1)
+	g.AddEdge("tl", "roof", 1)
+	g.AddEdge("roof", "tr", 1)
+	g.AddEdge("bl", "tr", 1)
+	g.AddEdge("tl", "br", 1)
+
+	if _, ok := EulerianCircuit(g); ok {
+		t.Error("house has odd vertices and no circuit")
+	}
+	walk, ok := EulerianPath(g)
+	if !ok {
+		t.Fatal("house should have an Eulerian path")
+	}
+	checkEulerWalk(t, g, walk)
+	ends := map[string]bool{walk[0]: true, walk[len(walk)-1]: true}
+	if !ends["bl"] || !ends["br"] {
+		t.Errorf("path %v should run between the odd vertices", walk)
+	}
+}
+
+func TestEulerianSelfLoop(t *testing.T) {
+	g := New[int](false)
+	g.AddEdge(1, 2, 1)
+	g.AddEdge(2, 2, 1)
+	g.AddEdge(2, 3, 1)
+
+	walk, ok := EulerianPath(g)
+	if !ok {
+		t.Fatal("path with a self-loop should be Eulerian")
+	}
+	checkEulerWalk(t, g, walk)
+}
+
+func TestEulerianDirectedPath(t *testing.T) {
+	g := New[int](true)
+	g.AddEdge(1, 2, 1)
+	g.AddEdge(2, 3, 1)
+	g.AddEdge(3, 1, 1)
+	g.AddEdge(1, 4, 1)
+
+	if _, ok := EulerianCircuit(g); ok {
+		t.Error("unbalanced graph should have no circuit")
+	}
+	walk, ok := EulerianPath(g)
+	if !ok {
+		t.Fatal("graph should have an Eulerian path")
+	}
+	checkEulerWalk(t, g, walk)
+	if walk[0] != 1 || walk[len(walk)-1] != 4 {
+		t.Errorf("path %v should run from 1 to 4", walk)
+	}
+}
+
+func TestNotEulerian(t *testing.T) {
+	t.Run("disconnected", func(t *testing.T) {
+		g := New[int](false)
+		g.AddEdge(1, 2, 1)
+		g.AddEdge(2, 3, 1)
+		g.AddEdge(3, 1, 1)
+		g.AddEdge(4, 5, 1)
+		g.AddEdge(5, 6, 1)
+		g.AddEdge(6, 4, 1)
+		if _, ok := EulerianPath(g); ok {
+			t.Error("two separate triangles have no Eulerian path")
+		}
+	})
+
+	t.Run("too many odd vertices", func(t *testing.T) {
+		g := New[int](false)
+		g.AddEdge(0, 1, 1)
+		g.AddEdge(0, 2, 1)
+		g.AddEdge(0, 3, 1)
+		if _, ok := EulerianPath(g); ok {
+			t.Error("star with three leaves has no Eulerian path")
+		}
+	})
+
+	t.Run("two sources", func(t *testing.T) {
+		g := New[int](true)
+		g.AddEdge(1, 3, 1)
+		g.AddEdge(2, 3, 1)
+		if _, ok := EulerianPath(g); ok {
+			t.Error("two sources cannot share an Eulerian path")
+		}
+	})
+
+	t.Run("no edges", func(t *testing.T) {
+		g := New[int](true)
+		g.AddVertex(1)
+		if _, ok := EulerianCircuit(g); ok {
+			t.Error("edgeless graph should not report a circuit")
+		}
+	})
+}
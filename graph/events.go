@@ -0,0 +1,73 @@
+package graph
+
+import "slices"
+
+// EventKind identifies the kind of change described by an Event.
+type EventKind int
+
+const (
+	// VertexAdded is sent when a new vertex is added.
+	VertexAdded EventKind = iota + 1
+	// VertexRemoved is sent after a vertex is removed. The removal of each
+	// of its edges is reported first.
+	VertexRemoved
+	// EdgeAdded is sent when a new edge is added.
+	EdgeAdded
+	// EdgeUpdated is sent when AddEdge changes the weight of an existing edge.
+	EdgeUpdated
+	// EdgeRemoved is sent when an edge is removed, whether by RemoveEdge or
+	// as part of RemoveVertex.
+	EdgeRemoved
+)
+
+func (k EventKind) String() string {
+	switch k {
+	case VertexAdded:
+		return "VertexAdded"
+	case VertexRemoved:
+		return "VertexRemoved"
+	case EdgeAdded:
+		return "EdgeAdded"
+	case EdgeUpdated:
+		return "EdgeUpdated"
+	case EdgeRemoved:
+		return "EdgeRemoved"
+	}
+	return "EventKind(?)"
+}
+
+// Event describes a change to a Graph. Vertex is set for vertex events and
+// Edge for edge events; an undirected edge is reported once, in the
+// direction it was added or removed. OldWeight is the previous weight for
+// EdgeUpdated.
+type Event[T comparable] struct {
+	Kind      EventKind
+	Vertex    T
+	Edge      Edge[T]
+	OldWeight float64
+}
+
+type observer[T comparable] struct {
+	fn func(Event[T])
+}
+
+// Observe registers fn to be called synchronously after every change to the
+// graph, in the order the changes happen. Changes that leave the graph as
+// it was, such as adding an existing vertex, are not reported. It returns a
+// function that unregisters fn.
+//
+// fn must not modify the graph.
+func (g *Graph[T]) Observe(fn func(Event[T])) (cancel func()) {
+	o := &observer[T]{fn}
+	g.observers = append(g.observers, o)
+	return func() {
+		// Copy so that an emit in progress keeps its own slice.
+		g.observers = slices.DeleteFunc(slices.Clone(g.observers), func(x *observer[T]) bool { return x == o })
+	}
+}
+
+func (g *Graph[T]) emit(e Event[T]) {
+	for _, o := range g.observers {
+		o.fn(e)
+	}
+}
@@ -0,0 +1,125 @@
+package graph
+
+import (
+	"slices"
+	"testing"
+)
+
+func record[T comparable](g *Graph[T]) (*[]Event[T], func()) {
+	var events []Event[T]
+	cancel := g.Observe(func(e Event[T]) { events = append(events, e) })
+	return &events, cancel
+}
+
+func TestObserveAdd(t *testing.T) {
+	g := New[string](true)
+	events, _ := record(g)
+
+	g.AddEdge("a", "b", 1)
+	g.AddVertex("a")       // already present
+	g.AddEdge("a", "b", 1) // same weight
+	g.AddEdge("a", "b", 3)
+
+	want := []Event[string]{
+		{Kind: VertexAdded, Vertex: "a"},
+		{Kind: VertexAdded, Vertex: "b"},
+		{Kind: EdgeAdded, Edge: Edge[string]{"a", "b", 1}},
+		{Kind: EdgeUpdated, Edge: Edge[string]{"a", "b", 3}, OldWeight: 1},
+	}
+	if !slices.Equal(*events, want) {
+		t.Errorf("events = %v, want %v", *events, want)
+	}
+}
+
+func TestObserveRemove(t *testing.T) {
+	g := New[int](true)
+	g.AddEdge(1, 2, 1)
+	g.AddEdge(3, 1, 2)
+	g.AddEdge(1, 1, 4)
+	g.AddEdge(2, 3, 1)
+	events, _ := record(g)
+
+	g.RemoveEdge(2, 1) // does not exist
+	g.RemoveEdge(2, 3)
+	g.RemoveVertex(1)
+	g.RemoveVertex(42)
+
+	if len(*events) != 5 {
+		t.Fatalf("events = %v, want 5", *events)
+	}
+	if e := (*events)[0]; e.Kind != EdgeRemoved || e.Edge != (Edge[int]{2, 3, 1}) {
+		t.Errorf("first event = %v, want removal of 2->3", e)
+	}
+	removed := make(map[Edge[int]]bool)
+	for _, e := range (*events)[1:4] {
+		if e.Kind != EdgeRemoved {
+			t.Fatalf("event %v, want edge removals before the vertex", e)
+		}
+		removed[e.Edge] = true
+	}
+	for _, e := range []Edge[int]{{1, 2, 1}, {3, 1, 2}, {1, 1, 4}} {
+		if !removed[e] {
+			t.Errorf("implicit removal of %v not reported", e)
+		}
+	}
+	if e := (*events)[4]; e.Kind != VertexRemoved || e.Vertex != 1 {
+		t.Errorf("last event = %v, want VertexRemoved 1", e)
+	}
+}
+
+func TestObserveUndirected(t *testing.T) {
+	g := New[int](false)
+	events, _ := record(g)
+	g.AddEdge(1, 2, 1)
+	g.AddEdge(2, 1, 5)
+	g.RemoveVertex(2)
+
+	kinds := make([]EventKind, len(*events))
+	for i, e := range *events {
+		kinds[i] = e.Kind
+	}
+	want := []EventKind{VertexAdded, VertexAdded, EdgeAdded, EdgeUpdated, EdgeRemoved, VertexRemoved}
+	if !slices.Equal(kinds, want) {
+		t.Errorf("kinds = %v, want %v", kinds, want)
+	}
+}
+
+func TestObserveCancel(t *testing.T) {
+	g := New[int](false)
+	a, cancelA := record(g)
+	b, _ := record(g)
+
+	g.AddVertex(1)
+	cancelA()
+	g.AddVertex(2)
+
+	if len(*a) != 1 || len(*b) != 2 {
+		t.Errorf("got %d and %d events, want 1 and 2", len(*a), len(*b))
+	}
+
+	// Observers may unregister themselves while being notified.
+	var cancelSelf func()
+	calls := 0
+	cancelSelf = g.Observe(func(Event[int]) {
+		calls++
+		cancelSelf()
+	})
+	g.AddVertex(3)
+	g.AddVertex(4)
+	if calls != 1 || len(*b) != 4 {
+		t.Errorf("self-cancelling observer called %d times, other saw %d events", calls, len(*b))
+	}
+
+	if c := g.Clone(); len(c.observers) != 0 {
+		t.Error("Clone should not copy observers")
+	}
+}
+
+func TestEventKindString(t *testing.T) {
+	if s := EdgeRemoved.String(); s != "EdgeRemoved" {
+		t.Errorf("String() = %q", s)
+	}
+	if s := EventKind(0).String(); s != "EventKind(?)" {
+		t.Errorf("String() = %q", s)
+	}
+}
@@ -0,0 +1,172 @@
+package graph
+
+import "math/rand/v2"
+
+// The generators below number vertices 0..n-1, add them in that order and
+// give every edge weight 1. Random generators draw only from r, so a seeded
+// source such as rand.New(rand.NewPCG(seed, 0)) reproduces the same graph.
+
+func withVertices(n int, directed bool) *Graph[int] {
+	g := New[int](directed)
+	for i := 0; i < n; i++ {
+		g.AddVertex(i)
+	}
+	return g
+}
+
+// CompleteGraph returns the graph with an edge between every pair of
+// distinct vertices.
+func CompleteGraph(n int, directed bool) *Graph[int] {
+	g := withVertices(n, directed)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			if i != j && (directed || i < j) {
+				g.AddEdge(i, j, 1)
+			}
+		}
+	}
+	return g
+}
+
+// StarGraph returns an undirected star: vertex 0 joined to each of 1..n-1.
+func StarGraph(n int) *Graph[int] {
+	g := withVertices(n, false)
+	for i := 1; i < n; i++ {
+		g.AddEdge(0, i, 1)
+	}
+	return g
+}
+
+// CycleGraph returns the cycle 0 -> 1 -> ... -> n-1 -> 0.
+func CycleGraph(n int, directed bool) *Graph[int] {
+	g := PathGraph(n, directed)
+	if n > 2 || n == 2 && directed {
+		g.AddEdge(n-1, 0, 1)
+	}
+	return g
+}
+
+// PathGraph returns the path 0 -> 1 -> ... -> n-1.
+func PathGraph(n int, directed bool) *Graph[int] {
+	g := withVertices(n, directed)
+	for i := 1; i < n; i++ {
+		g.AddEdge(i-1, i, 1)
+	}
+	return g
+}
+
+// GridGraph returns an undirected rows x cols lattice in which the vertex
+// in row r and column c is r*cols+c and is joined to its horizontal and
+// vertical neighbors.
+func GridGraph(rows, cols int) *Graph[int] {
+	g := withVertices(rows*cols, false)
+	for r := 0; r < rows; r++ {
+		for c := 0; c < cols; c++ {
+			v := r*cols + c
+			if c+1 < cols {
+				g.AddEdge(v, v+1, 1)
+			}
+			if r+1 < rows {
+				g.AddEdge(v, v+cols, 1)
+			}
+		}
+	}
+	return g
+}
+
+// ErdosRenyi returns a G(n, p) random graph in which every possible edge
+// between distinct vertices is present independently with probability p.
+func ErdosRenyi(n int, p float64, directed bool, r *rand.Rand) *Graph[int] {
+	g := withVertices(n, directed)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			if i != j && (directed || i < j) && r.Float64() < p {
+				g.AddEdge(i, j, 1)
+			}
+		}
+	}
+	return g
+}
+
+// RandomDAG returns a random directed acyclic graph in which each edge
+// i -> j with i < j is present independently with probability p. The vertex
+// numbering is therefore a topological order.
+func RandomDAG(n int, p float64, r *rand.Rand) *Graph[int] {
+	g := withVertices(n, true)
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			if r.Float64() < p {
+				g.AddEdge(i, j, 1)
+			}
+		}
+	}
+	return g
+}
+
+// BarabasiAlbert returns an undirected scale-free graph grown by
+// preferential attachment. It starts from a star on m+1 vertices; each
+// later vertex is joined to m distinct existing vertices chosen with
+// probability proportional to their degree. It panics unless 1 <= m < n.
+func BarabasiAlbert(n, m int, r *rand.Rand) *Graph[int] {
+	if m < 1 || m >= n {
+		panic("graph: BarabasiAlbert requires 1 <= m < n")
+	}
+	g := StarGraph(m + 1)
+	// Each vertex appears in ends once per incident edge.
+	var ends []int
+	for i := 1; i <= m; i++ {
+		ends = append(ends, 0, i)
+	}
+	for v := m + 1; v < n; v++ {
+		g.AddVertex(v)
+		targets := make([]int, 0, m)
+		chosen := make(map[int]bool, m)
+		for len(targets) < m {
+			t := ends[r.IntN(len(ends))]
+			if !chosen[t] {
+				chosen[t] = true
+				targets = append(targets, t)
+			}
+		}
+		for _, t := range targets {
+			g.AddEdge(v, t, 1)
+			ends = append(ends, v, t)
+		}
+	}
+	return g
+}
+
+// WattsStrogatz returns an undirected small-world graph. It starts from a
+// ring in which every vertex is joined to its k/2 nearest neighbors on each
+// side, then rewires each edge's far end with probability beta to a random
+// vertex, avoiding self-loops and duplicate edges. It panics unless k is
+// even and 0 <= k < n.
+func WattsStrogatz(n, k int, beta float64, r *rand.Rand) *Graph[int] {
+	if k%2 != 0 || k < 0 || k >= n {
+		panic("graph: WattsStrogatz requires an even k with 0 <= k < n")
+	}
+	g := withVertices(n, false)
+	for j := 1; j <= k/2; j++ {
+		for i := 0; i < n; i++ {
+			g.AddEdge(i, (i+j)%n, 1)
+		}
+	}
+	for j := 1; j <= k/2; j++ {
+		for u := 0; u < n; u++ {
+			v := (u + j) % n
+			if r.Float64() >= beta || len(g.adj[u]) >= n-1 {
+				continue
+			}
+			var w int
+			for {
+				w = r.IntN(n)
+				if _, dup := g.adj[u][w]; w != u && !dup {
+					break
+				}
+			}
+			g.RemoveEdge(u, v)
+			g.AddEdge(u, w, 1)
+		}
+	}
+	return g
+}
@@ -0,0 +1,220 @@
+package graph
+
+import (
+	"math/rand/v2"
+	"testing"
+)
+
+func edgeCount[T comparable](g *Graph[T]) int {
+	n := 0
+	for _, u := range g.Vertices() {
+		for v := range g.Neighbors(u) {
+			if g.directed || u != v {
+				n++
+			} else {
+				n += 2 // undirected self-loops are stored once
+			}
+		}
+	}
+	if !g.directed {
+		n /= 2
+	}
+	return n
+}
+
+func sameGraph(a, b *Graph[int]) bool {
+	va, vb := a.Vertices(), b.Vertices()
+	if len(va) != len(vb) || a.directed != b.directed {
+		return false
+	}
+	for i, u := range va {
+		if vb[i] != u || len(a.Neighbors(u)) != len(b.Neighbors(u)) {
+			return false
+		}
+		for v, w := range a.Neighbors(u) {
+			if bw, ok := b.Neighbors(u)[v]; !ok || bw != w {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func hasSelfLoop(g *Graph[int]) bool {
+	for _, v := range g.Vertices() {
+		if _, ok := g.Neighbors(v)[v]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+func TestStructuredGenerators(t *testing.T) {
+	cases := []struct {
+		name     string
+		g        *Graph[int]
+		vertices int
+		edges    int
+	}{
+		{"complete", CompleteGraph(5, false), 5, 10},
+		{"complete directed", CompleteGraph(5, true), 5, 20},
+		{"star", StarGraph(6), 6, 5},
+		{"cycle", CycleGraph(6, false), 6, 6},
+		{"cycle directed", CycleGraph(6, true), 6, 6},
+		{"cycle of two", CycleGraph(2, false), 2, 1},
+		{"path", PathGraph(6, true), 6, 5},
+		{"grid", GridGraph(3, 4), 12, 17},
+		{"empty", CompleteGraph(0, false), 0, 0},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if n := len(c.g.Vertices()); n != c.vertices {
+				t.Errorf("vertices = %d, want %d", n, c.vertices)
+			}
+			if m := edgeCount(c.g); m != c.edges {
+				t.Errorf("edges = %d, want %d", m, c.edges)
+			}
+			for i, v := range c.g.Vertices() {
+				if v != i {
+					t.Fatalf("vertices = %v, want 0..n-1 in order", c.g.Vertices())
+				}
+			}
+		})
+	}
+}
+
+func TestGridGraphNeighbors(t *testing.T) {
+	g := GridGraph(3, 3)
+	if len(g.Neighbors(4)) != 4 {
+		t.Errorf("center has %d neighbors, want 4", len(g.Neighbors(4)))
+	}
+	if len(g.Neighbors(0)) != 2 {
+		t.Errorf("corner has %d neighbors, want 2", len(g.Neighbors(0)))
+	}
+	if _, ok := g.Neighbors(2)[3]; ok {
+		t.Error("row ends should not wrap around")
+	}
+}
+
+func TestErdosRenyi(t *testing.T) {
+	g := ErdosRenyi(200, 0.1, false, rand.New(rand.NewPCG(1, 2)))
+	if len(g.Vertices()) != 200 {
+		t.Fatalf("vertices = %d, want 200", len(g.Vertices()))
+	}
+	// Expected edges: 0.1 * 200*199/2 = 1990
+	if m := edgeCount(g); m < 1800 || m > 2200 {
+		t.Errorf("edges = %d, want about 1990", m)
+	}
+	if hasSelfLoop(g) {
+		t.Error("G(n, p) should have no self-loops")
+	}
+
+	if m := edgeCount(ErdosRenyi(10, 1, true, rand.New(rand.NewPCG(1, 2)))); m != 90 {
+		t.Errorf("p=1 directed edges = %d, want 90", m)
+	}
+	if m := edgeCount(ErdosRenyi(10, 0, false, rand.New(rand.NewPCG(1, 2)))); m != 0 {
+		t.Errorf("p=0 edges = %d, want 0", m)
+	}
+}
+
+func TestRandomDAG(t *testing.T) {
+	g := RandomDAG(50, 0.3, rand.New(rand.NewPCG(3, 4)))
+	for _, u := range g.Vertices() {
+		for v := range g.Neighbors(u) {
+			if v <= u {
+				t.Fatalf("edge %d->%d goes against vertex order", u, v)
+			}
+		}
+	}
+	if edgeCount(g) == 0 {
+		t.Error("random DAG should have some edges")
+	}
+}
+
+func TestBarabasiAlbert(t *testing.T) {
+	n, m := 500, 3
+	g := BarabasiAlbert(n, m, rand.New(rand.NewPCG(5, 6)))
+
+	if len(g.Vertices()) != n {
+		t.Fatalf("vertices = %d, want %d", len(g.Vertices()), n)
+	}
+	if got, want := edgeCount(g), m+(n-m-1)*m; got != want {
+		t.Errorf("edges = %d, want %d", got, want)
+	}
+	maxDegree := 0
+	for _, v := range g.Vertices() {
+		d := len(g.Neighbors(v))
+		if v > m && d < m {
+			t.Errorf("vertex %d has degree %d, want at least %d", v, d, m)
+		}
+		maxDegree = max(maxDegree, d)
+	}
+	// Preferential attachment grows hubs well beyond the average degree of 6.
+	if maxDegree < 30 {
+		t.Errorf("max degree = %d, expected a hub", maxDegree)
+	}
+	if hasSelfLoop(g) {
+		t.Error("Barabási–Albert graph should have no self-loops")
+	}
+}
+
+func TestWattsStrogatz(t *testing.T) {
+	lattice := WattsStrogatz(20, 4, 0, rand.New(rand.NewPCG(1, 1)))
+	for _, v := range lattice.Vertices() {
+		if len(lattice.Neighbors(v)) != 4 {
+			t.Fatalf("vertex %d has degree %d in the ring lattice, want 4", v, len(lattice.Neighbors(v)))
+		}
+	}
+
+	g := WattsStrogatz(100, 6, 0.3, rand.New(rand.NewPCG(7, 8)))
+	if m := edgeCount(g); m != 300 {
+		t.Errorf("edges = %d, want 300 (rewiring keeps the count)", m)
+	}
+	if hasSelfLoop(g) {
+		t.Error("rewiring should not create self-loops")
+	}
+	if sameGraph(g, WattsStrogatz(100, 6, 0, rand.New(rand.NewPCG(7, 8)))) {
+		t.Error("beta=0.3 should rewire some edges")
+	}
+}
+
+func TestGeneratorsPanic(t *testing.T) {
+	for name, f := range map[string]func(){
+		"BarabasiAlbert m=0":  func() { BarabasiAlbert(5, 0, rand.New(rand.NewPCG(1, 1))) },
+		"BarabasiAlbert m>=n": func() { BarabasiAlbert(3, 3, rand.New(rand.NewPCG(1, 1))) },
+		"WattsStrogatz odd k": func() { WattsStrogatz(10, 3, 0.1, rand.New(rand.NewPCG(1, 1))) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s should panic", name)
+				}
+			}()
+			f()
+		}()
+	}
+}
+
+func TestGeneratorsReproducible(t *testing.T) {
+	seeded := func() *rand.Rand { return rand.New(rand.NewPCG(42, 7)) }
+	for name, gen := range map[string]func(*rand.Rand) *Graph[int]{
+		"ErdosRenyi":     func(r *rand.Rand) *Graph[int] { return ErdosRenyi(60, 0.1, true, r) },
+		"RandomDAG":      func(r *rand.Rand) *Graph[int] { return RandomDAG(60, 0.1, r) },
+		"BarabasiAlbert": func(r *rand.Rand) *Graph[int] { return BarabasiAlbert(60, 2, r) },
+		"WattsStrogatz":  func(r *rand.Rand) *Graph[int] { return WattsStrogatz(60, 4, 0.2, r) },
+	} {
+		if !sameGraph(gen(seeded()), gen(seeded())) {
+			t.Errorf("%s should be reproducible from the same seed", name)
+		}
+		if sameGraph(gen(seeded()), gen(rand.New(rand.NewPCG(1, 2)))) {
+			t.Errorf("%s should vary with the seed", name)
+		}
+	}
+}
+
+func BenchmarkErdosRenyi(b *testing.B) {
+	r := rand.New(rand.NewPCG(1, 1))
+	for i := 0; i < b.N; i++ {
+		ErdosRenyi(500, 0.01, false, r)
+	}
+}
@@ -2,13 +2,18 @@
 //
 // ⚠️  NOT THREAD-SAFE
 // This implementation is not safe for concurrent access.
-// Wrap with external synchronization (sync.Mutex) if needed.
+// Wrap with external synchronization (sync.Mutex) if needed, or use
+// Concurrent.
 package graph
 
+import "slices"
+
 // Graph is a simple adjacency-list graph supporting directed or undirected edges.
 type Graph[T comparable] struct {
-	directed bool
-	adj      map[T]map[T]float64
+	directed  bool
+	adj       map[T]map[T]float64
+	order     []T // vertices in insertion order
+	observers []*observer[T]
 }
 
 // New creates a new graph. If directed is true, edges are one-way.
@@ -20,59 +25,90 @@ func New[T comparable](directed bool) *Graph[T] {
 func (g *Graph[T]) AddVertex(v T) {
 	if _, ok := g.adj[v]; !ok {
 		g.adj[v] = make(map[T]float64)
+		g.order = append(g.order, v)
+		g.emit(Event[T]{Kind: VertexAdded, Vertex: v})
 	}
 }
 
+// HasVertex reports whether v is in the graph.
+func (g *Graph[T]) HasVertex(v T) bool {
+	_, ok := g.adj[v]
+	return ok
+}
+
 // AddEdge adds an edge u->v with weight w. For undirected graphs, adds both ways.
 func (g *Graph[T]) AddEdge(u, v T, w float64) {
 	g.AddVertex(u)
 	g.AddVertex(v)
+	old, exists := g.adj[u][v]
 	g.adj[u][v] = w
 	if !g.directed {
 		g.adj[v][u] = w
 	}
+	switch {
+	case !exists:
+		g.emit(Event[T]{Kind: EdgeAdded, Edge: Edge[T]{From: u, To: v, Weight: w}})
+	case old != w:
+		g.emit(Event[T]{Kind: EdgeUpdated, Edge: Edge[T]{From: u, To: v, Weight: w}, OldWeight: old})
+	}
 }
 
 // Neighbors returns the neighbor-weight map for v (may be empty).
+// The map belongs to the graph and must not be modified; use AddEdge and
+// RemoveEdge instead.
 func (g *Graph[T]) Neighbors(v T) map[T]float64 { return g.adj[v] }
 
-// Vertices returns all vertices.
+// Vertices returns all vertices in the order they were added.
 func (g *Graph[T]) Vertices() []T {
-	out := make([]T, 0, len(g.adj))
-	for v := range g.adj {
-		out = append(out, v)
-	}
+	out := make([]T, len(g.order))
+	copy(out, g.order)
 	return out
 }
 
 // RemoveVertex removes a vertex and all edges connected to it.
 func (g *Graph[T]) RemoveVertex(v T) {
-	// Remove all edges pointing to v from other vertices
-	for vertex := range g.adj {
-		delete(g.adj[vertex], v)
+	if _, ok := g.adj[v]; !ok {
+		return
+	}
+	// Remove the edges one at a time so observers see each of them go.
+	for n := range g.adj[v] {
+		g.RemoveEdge(v, n)
+	}
+	if g.directed {
+		for u, neighbors := range g.adj {
+			if _, ok := neighbors[v]; ok {
+				g.RemoveEdge(u, v)
+			}
+		}
 	}
 	// Remove the vertex itself
 	delete(g.adj, v)
+	i := slices.Index(g.order, v)
+	g.order = slices.Delete(g.order, i, i+1)
+	g.emit(Event[T]{Kind: VertexRemoved, Vertex: v})
 }
 
 // RemoveEdge removes an edge from u to v.
 // For undirected graphs, removes both u->v and v->u.
 func (g *Graph[T]) RemoveEdge(u, v T) {
-	if neighbors, ok := g.adj[u]; ok {
-		delete(neighbors, v)
+	w, ok := g.adj[u][v]
+	if !ok {
+		return
 	}
+	delete(g.adj[u], v)
 	if !g.directed {
-		if neighbors, ok := g.adj[v]; ok {
-			delete(neighbors, u)
-		}
+		delete(g.adj[v], u)
 	}
+	g.emit(Event[T]{Kind: EdgeRemoved, Edge: Edge[T]{From: u, To: v, Weight: w}})
 }
 
-// Clone returns a deep copy of the graph.
+// Clone returns a deep copy of the graph. Observers are not copied.
 func (g *Graph[T]) Clone() *Graph[T] {
 	clone := New[T](g.directed)
+	for _, vertex := range g.order {
+		clone.AddVertex(vertex)
+	}
 	for vertex, neighbors := range g.adj {
-		clone.adj[vertex] = make(map[T]float64)
 		for neighbor, weight := range neighbors {
 			clone.adj[vertex][neighbor] = weight
 		}
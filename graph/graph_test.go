@@ -555,3 +555,28 @@ func BenchmarkRemoveVertex(b *testing.B) {
 	}
 }
 
+func TestVerticesInsertionOrder(t *testing.T) {
+	g := New[string](false)
+	g.AddEdge("c", "a", 1)
+	g.AddVertex("b")
+	g.AddEdge("a", "d", 1)
+	g.RemoveVertex("a")
+	g.AddVertex("a")
+
+	want := []string{"c", "b", "d", "a"}
+	got := g.Vertices()
+	if len(got) != len(want) {
+		t.Fatalf("vertices = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("vertices = %v, want %v", got, want)
+		}
+	}
+
+	clone := g.Clone()
+	clone.AddVertex("e")
+	if len(g.Vertices()) != 4 {
+		t.Error("adding to clone should not affect original order")
+	}
+}
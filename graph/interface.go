@@ -0,0 +1,96 @@
+package graph
+
+import "iter"
+
+// Interface is the read-only view of a graph used by the algorithms in this
+// package. Graph, CSR and MultiGraph implement it, and so can user types
+// that generate their edges on the fly, such as puzzle states or grid maps.
+type Interface[T comparable] interface {
+	// Successors yields every vertex reachable from v over a single edge,
+	// together with the weight of that edge.
+	Successors(v T) iter.Seq2[T, float64]
+}
+
+// SuccessorsFunc adapts an ordinary function to Interface.
+type SuccessorsFunc[T comparable] func(v T) iter.Seq2[T, float64]
+
+// Successors calls f(v).
+func (f SuccessorsFunc[T]) Successors(v T) iter.Seq2[T, float64] { return f(v) }
+
+// Successors yields the neighbors of v with their edge weights.
+func (g *Graph[T]) Successors(v T) iter.Seq2[T, float64] {
+	return func(yield func(T, float64) bool) {
+		for n, w := range g.adj[v] {
+			if !yield(n, w) {
+				return
+			}
+		}
+	}
+}
+
+// Successors yields the neighbors of v with their edge weights in ID order.
+func (c *CSR[T]) Successors(v T) iter.Seq2[T, float64] {
+	return func(yield func(T, float64) bool) {
+		id, ok := c.index[v]
+		if !ok {
+			return
+		}
+		targets, weights := c.Adjacent(id)
+		for i, t := range targets {
+			if !yield(c.vertices[t], weights[i]) {
+				return
+			}
+		}
+	}
+}
+
+// Successors yields the far endpoint and weight of every edge leaving v.
+// Parallel edges are yielded separately.
+func (g *MultiGraph[T]) Successors(v T) iter.Seq2[T, float64] {
+	return func(yield func(T, float64) bool) {
+		for id := range g.adj[v] {
+			if !yield(g.other(id, v), g.edges[id].Weight) {
+				return
+			}
+		}
+	}
+}
+
+// Reversible is an Interface that can also enumerate the edges entering a
+// vertex. Graph and ReverseIndex implement it.
+type Reversible[T comparable] interface {
+	Interface[T]
+	// Predecessors yields every vertex with an edge into v, together with
+	// the weight of that edge.
+	Predecessors(v T) iter.Seq2[T, float64]
+}
+
+// Predecessors yields the vertices with an edge into v and their weights.
+// For undirected graphs this is the same as Successors. Graph stores only
+// outgoing edges, so for directed graphs each call scans every vertex; use
+// a ReverseIndex for repeated lookups.
+func (g *Graph[T]) Predecessors(v T) iter.Seq2[T, float64] {
+	if !g.directed {
+		return g.Successors(v)
+	}
+	return func(yield func(T, float64) bool) {
+		for _, u := range g.order {
+			if w, ok := g.adj[u][v]; ok && !yield(u, w) {
+				return
+			}
+		}
+	}
+}
+
+// predecessors returns g.Predecessors, except for a directed Graph, where it
+// first builds a ReverseIndex in O(V+E) so that algorithms making many
+// lookups do not pay for a scan of every vertex on each one.
+func predecessors[T comparable](g Reversible[T]) func(T) iter.Seq2[T, float64] {
+	dg, ok := g.(*Graph[T])
+	if !ok || !dg.directed {
+		return g.Predecessors
+	}
+	r := NewReverseIndex(dg)
+	r.Close() // only used for the duration of one call
+	return r.Predecessors
+}
@@ -0,0 +1,140 @@
+package graph
+
+import (
+	"iter"
+	"maps"
+	"testing"
+)
+
+func collectSuccessors[T comparable](g Interface[T], v T) map[T]float64 {
+	out := make(map[T]float64)
+	for n, w := range g.Successors(v) {
+		out[n] = w
+	}
+	return out
+}
+
+func TestSuccessorsImplementations(t *testing.T) {
+	g := New[int](false)
+	g.AddEdge(1, 2, 3)
+	g.AddEdge(1, 3, 4)
+
+	impls := map[string]Interface[int]{
+		"Graph":      g,
+		"CSR":        g.Freeze(),
+		"MultiGraph": FromGraph(g),
+	}
+	for name, impl := range impls {
+		t.Run(name, func(t *testing.T) {
+			got := collectSuccessors(impl, 1)
+			if len(got) != 2 || got[2] != 3 || got[3] != 4 {
+				t.Errorf("Successors(1) = %v, want map[2:3 3:4]", got)
+			}
+			if got := collectSuccessors(impl, 2); len(got) != 1 || got[1] != 3 {
+				t.Errorf("Successors(2) = %v, want map[1:3]", got)
+			}
+			if got := collectSuccessors(impl, 99); len(got) != 0 {
+				t.Errorf("Successors(99) = %v, want empty", got)
+			}
+		})
+	}
+}
+
+func TestSuccessorsEarlyStop(t *testing.T) {
+	g := New[int](true)
+	for i := 1; i <= 5; i++ {
+		g.AddEdge(0, i, 1)
+	}
+	for name, impl := range map[string]Interface[int]{"Graph": g, "CSR": g.Freeze(), "MultiGraph": FromGraph(g)} {
+		n := 0
+		for range impl.Successors(0) {
+			n++
+			if n == 2 {
+				break
+			}
+		}
+		if n != 2 {
+			t.Errorf("%s: iteration did not stop on break", name)
+		}
+	}
+}
+
+func TestSuccessorsFunc(t *testing.T) {
+	// Implicit graph: each integer links to its double and its successor.
+	g := SuccessorsFunc[int](func(v int) iter.Seq2[int, float64] {
+		return func(yield func(int, float64) bool) {
+			_ = yield(v*2, 1) && yield(v+1, 1)
+		}
+	})
+
+	got := collectSuccessors[int](g, 3)
+	if len(got) != 2 || got[6] != 1 || got[4] != 1 {
+		t.Errorf("Successors(3) = %v, want map[4:1 6:1]", got)
+	}
+}
+
+func collectPredecessors[T comparable](g Reversible[T], v T) map[T]float64 {
+	out := make(map[T]float64)
+	for n, w := range g.Predecessors(v) {
+		out[n] = w
+	}
+	return out
+}
+
+func TestPredecessors(t *testing.T) {
+	g := New[int](true)
+	g.AddEdge(1, 3, 1)
+	g.AddEdge(2, 3, 2)
+	g.AddEdge(3, 4, 3)
+
+	if got := collectPredecessors[int](g, 3); len(got) != 2 || got[1] != 1 || got[2] != 2 {
+		t.Errorf("Predecessors(3) = %v, want map[1:1 2:2]", got)
+	}
+
+	g.RemoveEdge(1, 3)
+	if got := collectPredecessors[int](g, 3); len(got) != 1 || got[2] != 2 {
+		t.Errorf("after RemoveEdge, Predecessors(3) = %v, want map[2:2]", got)
+	}
+
+	g.RemoveVertex(3)
+	if got := collectPredecessors[int](g, 4); len(got) != 0 {
+		t.Errorf("after RemoveVertex, Predecessors(4) = %v, want empty", got)
+	}
+	if len(g.Neighbors(2)) != 0 {
+		t.Error("RemoveVertex should remove incoming edges")
+	}
+
+	clone := New[int](true)
+	clone.AddEdge(5, 6, 1)
+	clone = clone.Clone()
+	if got := collectPredecessors[int](clone, 6); len(got) != 1 || got[5] != 1 {
+		t.Errorf("clone Predecessors(6) = %v, want map[5:1]", got)
+	}
+}
+
+func TestPredecessorsUndirected(t *testing.T) {
+	g := New[int](false)
+	g.AddEdge(1, 2, 5)
+	if got := collectPredecessors[int](g, 1); len(got) != 1 || got[2] != 5 {
+		t.Errorf("Predecessors(1) = %v, want map[2:5]", got)
+	}
+}
+
+func TestPredecessorsIndex(t *testing.T) {
+	g := New[int](true)
+	for i := 0; i < 20; i++ {
+		g.AddEdge(i, (i*3)%20, float64(i))
+		g.AddEdge(i, (i+1)%20, 1)
+	}
+	pred := predecessors[int](g)
+	for v := 0; v < 20; v++ {
+		want := collectPredecessors[int](g, v)
+		got := make(map[int]float64)
+		for p, w := range pred(v) {
+			got[p] = w
+		}
+		if !maps.Equal(got, want) {
+			t.Errorf("predecessors(%d) = %v, want %v", v, got, want)
+		}
+	}
+}
@@ -0,0 +1,278 @@
+package graph
+
+import "iter"
+
+// MatchOptions customises Isomorphic and SubgraphIsomorphisms. Nil fields
+// accept every pair.
+type MatchOptions[T, U comparable] struct {
+	// VertexEqual reports whether vertex a of the first graph may be mapped
+	// to vertex b of the second.
+	VertexEqual func(a T, b U) bool
+	// EdgeEqual reports whether an edge of weight a in the first graph may
+	// be mapped to an edge of weight b in the second.
+	EdgeEqual func(a, b float64) bool
+}
+
+// Isomorphic reports whether g1 and g2 are isomorphic and, if so, returns a
+// mapping from the vertices of g1 to those of g2 that preserves edges in
+// both directions. It uses the VF2 algorithm.
+func Isomorphic[T, U comparable](g1 *Graph[T], g2 *Graph[U], opts MatchOptions[T, U]) (map[T]U, bool) {
+	if g1.directed != g2.directed || len(g1.adj) != len(g2.adj) || arcCount(g1) != arcCount(g2) {
+		return nil, false
+	}
+	for m := range vf2(g1, g2, opts, false) {
+		return m, true
+	}
+	return nil, false
+}
+
+// SubgraphIsomorphisms yields every mapping of the vertices of pattern onto
+// distinct vertices of host such that two pattern vertices are joined by an
+// edge exactly when their images are, i.e. every occurrence of pattern as an
+// induced subgraph of host. Automorphic embeddings are reported separately.
+// It uses the VF2 algorithm.
+func SubgraphIsomorphisms[T, U comparable](pattern *Graph[T], host *Graph[U], opts MatchOptions[T, U]) iter.Seq[map[T]U] {
+	if pattern.directed != host.directed || len(pattern.adj) > len(host.adj) {
+		return func(func(map[T]U) bool) {}
+	}
+	return vf2(pattern, host, opts, true)
+}
+
+func arcCount[T comparable](g *Graph[T]) int {
+	n := 0
+	for _, neighbors := range g.adj {
+		n += len(neighbors)
+	}
+	return n
+}
+
+// vf2Graph is a graph over vertex indices with successor and predecessor
+// weights. For undirected graphs pred and succ are the same.
+type vf2Graph struct {
+	succ []map[int]float64
+	pred []map[int]float64
+}
+
+func vf2Index[T comparable](g *Graph[T]) ([]T, vf2Graph) {
+	vertices := g.Vertices()
+	index := make(map[T]int, len(vertices))
+	for i, v := range vertices {
+		index[v] = i
+	}
+	vg := vf2Graph{succ: make([]map[int]float64, len(vertices))}
+	for i, u := range vertices {
+		vg.succ[i] = make(map[int]float64, len(g.adj[u]))
+		for v, w := range g.adj[u] {
+			vg.succ[i][index[v]] = w
+		}
+	}
+	vg.pred = vg.succ
+	if g.directed {
+		vg.pred = make([]map[int]float64, len(vertices))
+		for i := range vertices {
+			vg.pred[i] = make(map[int]float64)
+		}
+		for i, m := range vg.succ {
+			for j, w := range m {
+				vg.pred[j][i] = w
+			}
+		}
+	}
+	return vertices, vg
+}
+
+// vf2State is the search state of VF2 for one side of the match. core maps
+// a vertex to its partner or -1; in and out record the depth at which a
+// vertex joined the terminal sets of predecessors and successors of the
+// partial mapping, or 0.
+type vf2State struct {
+	g       vf2Graph
+	core    []int
+	in, out []int
+}
+
+func newVF2State(g vf2Graph) *vf2State {
+	n := len(g.succ)
+	s := &vf2State{g: g, core: make([]int, n), in: make([]int, n), out: make([]int, n)}
+	for i := range s.core {
+		s.core[i] = -1
+	}
+	return s
+}
+
+func (s *vf2State) add(v, partner, depth int) {
+	s.core[v] = partner
+	if s.in[v] == 0 {
+		s.in[v] = depth
+	}
+	if s.out[v] == 0 {
+		s.out[v] = depth
+	}
+	for n := range s.g.succ[v] {
+		if s.out[n] == 0 {
+			s.out[n] = depth
+		}
+	}
+	for n := range s.g.pred[v] {
+		if s.in[n] == 0 {
+			s.in[n] = depth
+		}
+	}
+}
+
+func (s *vf2State) remove(v, depth int) {
+	s.core[v] = -1
+	for i := range s.core {
+		if s.in[i] == depth {
+			s.in[i] = 0
+		}
+		if s.out[i] == depth {
+			s.out[i] = 0
+		}
+	}
+}
+
+// counts classifies the unmatched neighbors in nbrs as members of the
+// predecessor terminal set, the successor terminal set, or neither.
+func (s *vf2State) counts(nbrs map[int]float64) (tin, tout, fresh int) {
+	for n := range nbrs {
+		if s.core[n] >= 0 {
+			continue
+		}
+		if s.in[n] > 0 {
+			tin++
+		}
+		if s.out[n] > 0 {
+			tout++
+		}
+		if s.in[n] == 0 && s.out[n] == 0 {
+			fresh++
+		}
+	}
+	return tin, tout, fresh
+}
+
+// vf2 yields mappings from g1 to g2. If sub is false the graphs must have
+// the same size and the mapping is an isomorphism; otherwise g1 is matched
+// against induced subgraphs of g2.
+func vf2[T, U comparable](g1 *Graph[T], g2 *Graph[U], opts MatchOptions[T, U], sub bool) iter.Seq[map[T]U] {
+	return func(yield func(map[T]U) bool) {
+		v1, ix1 := vf2Index(g1)
+		v2, ix2 := vf2Index(g2)
+		s1, s2 := newVF2State(ix1), newVF2State(ix2)
+
+		edgeOK := func(a, b float64) bool { return opts.EdgeEqual == nil || opts.EdgeEqual(a, b) }
+		// within compares counts: equal for isomorphism, at most for subgraphs.
+		within := func(a, b int) bool { return a == b || sub && a < b }
+
+		// matchEdges checks that every edge between v and an already
+		// matched vertex in one graph has a counterpart in the other.
+		matchEdges := func(a, b map[int]float64, core []int, w func(x, y float64) bool) bool {
+			for n, wa := range a {
+				if m := core[n]; m >= 0 {
+					wb, ok := b[m]
+					if !ok || !w(wa, wb) {
+						return false
+					}
+				}
+			}
+			return true
+		}
+
+		feasible := func(p, h int) bool {
+			if opts.VertexEqual != nil && !opts.VertexEqual(v1[p], v2[h]) {
+				return false
+			}
+			// Self-loops are not covered by the neighbor checks below,
+			// since p is not matched yet.
+			wp, loopP := ix1.succ[p][p]
+			wh, loopH := ix2.succ[h][h]
+			if loopP != loopH || loopP && !edgeOK(wp, wh) {
+				return false
+			}
+			swapped := func(x, y float64) bool { return edgeOK(y, x) }
+			if !matchEdges(ix1.succ[p], ix2.succ[h], s1.core, edgeOK) ||
+				!matchEdges(ix1.pred[p], ix2.pred[h], s1.core, edgeOK) ||
+				!matchEdges(ix2.succ[h], ix1.succ[p], s2.core, swapped) ||
+				!matchEdges(ix2.pred[h], ix1.pred[p], s2.core, swapped) {
+				return false
+			}
+			for _, nbrs := range [][2]map[int]float64{{ix1.succ[p], ix2.succ[h]}, {ix1.pred[p], ix2.pred[h]}} {
+				in1, out1, new1 := s1.counts(nbrs[0])
+				in2, out2, new2 := s2.counts(nbrs[1])
+				if !within(in1, in2) || !within(out1, out2) || !within(new1, new2) {
+					return false
+				}
+			}
+			return true
+		}
+
+		// candidates returns the next g1 vertex to match and the g2
+		// vertices it may be paired with: those in the matching terminal
+		// set if both graphs have one, otherwise every unmatched vertex.
+		candidates := func() (int, []int) {
+			for _, terminal := range []func(s *vf2State, i int) bool{
+				func(s *vf2State, i int) bool { return s.out[i] > 0 },
+				func(s *vf2State, i int) bool { return s.in[i] > 0 },
+			} {
+				p := -1
+				for i := range s1.core {
+					if s1.core[i] < 0 && terminal(s1, i) {
+						p = i
+						break
+					}
+				}
+				var hs []int
+				for j := range s2.core {
+					if s2.core[j] < 0 && terminal(s2, j) {
+						hs = append(hs, j)
+					}
+				}
+				if p >= 0 && len(hs) > 0 {
+					return p, hs
+				}
+			}
+			p := -1
+			var hs []int
+			for i := range s1.core {
+				if s1.core[i] < 0 {
+					p = i
+					break
+				}
+			}
+			for j := range s2.core {
+				if s2.core[j] < 0 {
+					hs = append(hs, j)
+				}
+			}
+			return p, hs
+		}
+
+		var match func(depth int) bool
+		match = func(depth int) bool {
+			p, hs := candidates()
+			if p < 0 {
+				m := make(map[T]U, len(v1))
+				for i, j := range s1.core {
+					m[v1[i]] = v2[j]
+				}
+				return yield(m)
+			}
+			for _, h := range hs {
+				if !feasible(p, h) {
+					continue
+				}
+				s1.add(p, h, depth)
+				s2.add(h, p, depth)
+				ok := match(depth + 1)
+				s1.remove(p, depth)
+				s2.remove(h, depth)
+				if !ok {
+					return false
+				}
+			}
+			return true
+		}
+		match(1)
+	}
+}
@@ -0,0 +1,285 @@
+package graph
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"testing"
+)
+
+// checkEmbedding verifies that m maps g1 into g2 preserving edges and,
+// among mapped vertices, non-edges.
+func checkEmbedding[T, U comparable](t *testing.T, g1 *Graph[T], g2 *Graph[U], m map[T]U) {
+	t.Helper()
+	if len(m) != len(g1.Vertices()) {
+		t.Fatalf("mapping %v does not cover all %d vertices", m, len(g1.Vertices()))
+	}
+	used := make(map[U]bool)
+	for _, v := range m {
+		if used[v] {
+			t.Fatalf("mapping %v is not injective", m)
+		}
+		used[v] = true
+	}
+	for _, a := range g1.Vertices() {
+		for _, b := range g1.Vertices() {
+			_, e1 := g1.Neighbors(a)[b]
+			_, e2 := g2.Neighbors(m[a])[m[b]]
+			if e1 != e2 {
+				t.Errorf("edge %v->%v present=%v but image present=%v", a, b, e1, e2)
+			}
+		}
+	}
+}
+
+func countEmbeddings[T, U comparable](pattern *Graph[T], host *Graph[U], opts MatchOptions[T, U]) int {
+	n := 0
+	for range SubgraphIsomorphisms(pattern, host, opts) {
+		n++
+	}
+	return n
+}
+
+func cycle(n int) *Graph[int] {
+	g := New[int](false)
+	for i := 0; i < n; i++ {
+		g.AddEdge(i, (i+1)%n, 1)
+	}
+	return g
+}
+
+func complete(n int) *Graph[int] {
+	g := New[int](false)
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			g.AddEdge(i, j, 1)
+		}
+	}
+	return g
+}
+
+func TestIsomorphic(t *testing.T) {
+	g1 := New[int](false)
+	g1.AddEdge(1, 2, 1)
+	g1.AddEdge(2, 3, 1)
+	g1.AddEdge(3, 4, 1)
+	g1.AddEdge(4, 1, 1)
+	g1.AddEdge(1, 3, 1)
+
+	g2 := New[string](false)
+	g2.AddEdge("a", "c", 1)
+	g2.AddEdge("c", "b", 1)
+	g2.AddEdge("b", "d", 1)
+	g2.AddEdge("d", "a", 1)
+	g2.AddEdge("c", "d", 1)
+
+	m, ok := Isomorphic(g1, g2, MatchOptions[int, string]{})
+	if !ok {
+		t.Fatal("graphs should be isomorphic")
+	}
+	checkEmbedding(t, g1, g2, m)
+}
+
+func TestNotIsomorphic(t *testing.T) {
+	// Same degree sequence, different structure.
+	twoTriangles := New[int](false)
+	twoTriangles.AddEdge(0, 1, 1)
+	twoTriangles.AddEdge(1, 2, 1)
+	twoTriangles.AddEdge(2, 0, 1)
+	twoTriangles.AddEdge(3, 4, 1)
+	twoTriangles.AddEdge(4, 5, 1)
+	twoTriangles.AddEdge(5, 3, 1)
+
+	if _, ok := Isomorphic(cycle(6), twoTriangles, MatchOptions[int, int]{}); ok {
+		t.Error("C6 and two triangles are not isomorphic")
+	}
+	if _, ok := Isomorphic(cycle(5), cycle(6), MatchOptions[int, int]{}); ok {
+		t.Error("graphs of different order are not isomorphic")
+	}
+	if _, ok := Isomorphic(cycle(4), New[int](true), MatchOptions[int, int]{}); ok {
+		t.Error("directed and undirected graphs are not isomorphic")
+	}
+}
+
+func TestIsomorphicDirected(t *testing.T) {
+	g1 := New[int](true)
+	g1.AddEdge(1, 2, 1)
+	g1.AddEdge(2, 3, 1)
+	g1.AddEdge(1, 3, 1)
+
+	g2 := New[int](true)
+	g2.AddEdge(30, 10, 1)
+	g2.AddEdge(10, 20, 1)
+	g2.AddEdge(30, 20, 1)
+
+	m, ok := Isomorphic(g1, g2, MatchOptions[int, int]{})
+	if !ok {
+		t.Fatal("transitive triangles should be isomorphic")
+	}
+	checkEmbedding(t, g1, g2, m)
+	if m[1] != 30 || m[2] != 10 || m[3] != 20 {
+		t.Errorf("mapping = %v, want 1->30 2->10 3->20", m)
+	}
+
+	cyclic := New[int](true)
+	cyclic.AddEdge(1, 2, 1)
+	cyclic.AddEdge(2, 3, 1)
+	cyclic.AddEdge(3, 1, 1)
+	if _, ok := Isomorphic(g1, cyclic, MatchOptions[int, int]{}); ok {
+		t.Error("transitive and cyclic triangles are not isomorphic")
+	}
+}
+
+func TestIsomorphicSelfLoops(t *testing.T) {
+	g1 := New[int](false)
+	g1.AddEdge(1, 2, 1)
+	g1.AddEdge(1, 1, 1)
+	g2 := New[int](false)
+	g2.AddEdge(1, 2, 1)
+	g2.AddEdge(2, 2, 1)
+
+	m, ok := Isomorphic(g1, g2, MatchOptions[int, int]{})
+	if !ok || m[1] != 2 || m[2] != 1 {
+		t.Errorf("mapping = %v, %v, want 1->2 2->1", m, ok)
+	}
+}
+
+func TestIsomorphicOptions(t *testing.T) {
+	type node struct {
+		name string
+		role string
+	}
+	g1 := New[node](false)
+	g1.AddEdge(node{"lb", "proxy"}, node{"api", "app"}, 1)
+	g1.AddEdge(node{"api", "app"}, node{"pg", "db"}, 2)
+
+	g2 := New[node](false)
+	g2.AddEdge(node{"pg2", "db"}, node{"api2", "app"}, 2)
+	g2.AddEdge(node{"api2", "app"}, node{"lb2", "proxy"}, 1)
+
+	sameRole := func(a, b node) bool { return a.role == b.role }
+	m, ok := Isomorphic(g1, g2, MatchOptions[node, node]{VertexEqual: sameRole})
+	if !ok || m[node{"lb", "proxy"}].name != "lb2" {
+		t.Errorf("role-preserving mapping = %v, %v", m, ok)
+	}
+
+	sameWeight := func(a, b float64) bool { return a == b }
+	if _, ok := Isomorphic(g1, g2, MatchOptions[node, node]{EdgeEqual: sameWeight}); !ok {
+		t.Error("weights line up, graphs should match")
+	}
+
+	g2.AddEdge(node{"pg2", "db"}, node{"api2", "app"}, 5)
+	if _, ok := Isomorphic(g1, g2, MatchOptions[node, node]{EdgeEqual: sameWeight}); ok {
+		t.Error("changed weight should prevent a match")
+	}
+	if _, ok := Isomorphic(g1, g2, MatchOptions[node, node]{VertexEqual: func(a, b node) bool { return false }}); ok {
+		t.Error("rejecting every vertex pair should prevent a match")
+	}
+}
+
+func TestIsomorphicRandomPermutation(t *testing.T) {
+	r := rand.New(rand.NewPCG(8, 9))
+	for _, directed := range []bool{false, true} {
+		for trial := 0; trial < 10; trial++ {
+			g1 := New[int](directed)
+			g2 := New[string](directed)
+			perm := r.Perm(25)
+			for i := 0; i < 25; i++ {
+				g1.AddVertex(i)
+				g2.AddVertex(fmt.Sprint(perm[i]))
+			}
+			for i := 0; i < 60; i++ {
+				u, v := r.IntN(25), r.IntN(25)
+				g1.AddEdge(u, v, 1)
+				g2.AddEdge(fmt.Sprint(perm[u]), fmt.Sprint(perm[v]), 1)
+			}
+			m, ok := Isomorphic(g1, g2, MatchOptions[int, string]{})
+			if !ok {
+				t.Fatalf("directed=%v trial %d: permuted graph should be isomorphic", directed, trial)
+			}
+			checkEmbedding(t, g1, g2, m)
+		}
+	}
+}
+
+func TestSubgraphIsomorphisms(t *testing.T) {
+	triangle := complete(3)
+
+	// K4 has 4 triangles, each found in 3! orientations.
+	if n := countEmbeddings(triangle, complete(4), MatchOptions[int, int]{}); n != 24 {
+		t.Errorf("triangles in K4 = %d, want 24", n)
+	}
+
+	path := New[int](false)
+	path.AddEdge(0, 1, 1)
+	path.AddEdge(1, 2, 1)
+
+	// Every three vertices of K4 form a triangle, so no induced paths.
+	if n := countEmbeddings(path, complete(4), MatchOptions[int, int]{}); n != 0 {
+		t.Errorf("induced paths in K4 = %d, want 0", n)
+	}
+	// C5 has 5 induced paths of length 2, each in 2 orientations.
+	host := cycle(5)
+	if n := countEmbeddings(path, host, MatchOptions[int, int]{}); n != 10 {
+		t.Errorf("induced paths in C5 = %d, want 10", n)
+	}
+	for m := range SubgraphIsomorphisms(path, host, MatchOptions[int, int]{}) {
+		checkEmbedding(t, path, host, m)
+	}
+}
+
+func TestSubgraphIsomorphismsDirected(t *testing.T) {
+	pattern := New[string](true)
+	pattern.AddEdge("svc", "db", 1)
+	pattern.AddEdge("svc", "cache", 1)
+
+	host := New[string](true)
+	host.AddEdge("web", "pg", 1)
+	host.AddEdge("web", "redis", 1)
+	host.AddEdge("worker", "pg", 1)
+
+	isDB := func(p, h string) bool { return (p == "db") == (h == "pg") }
+	var found []map[string]string
+	for m := range SubgraphIsomorphisms(pattern, host, MatchOptions[string, string]{VertexEqual: isDB}) {
+		found = append(found, m)
+	}
+	if len(found) != 1 {
+		t.Fatalf("found %d embeddings, want 1: %v", len(found), found)
+	}
+	m := found[0]
+	if m["svc"] != "web" || m["db"] != "pg" || m["cache"] != "redis" {
+		t.Errorf("embedding = %v", m)
+	}
+}
+
+func TestSubgraphIsomorphismsEarlyStop(t *testing.T) {
+	n := 0
+	for range SubgraphIsomorphisms(complete(3), complete(6), MatchOptions[int, int]{}) {
+		n++
+		if n == 5 {
+			break
+		}
+	}
+	if n != 5 {
+		t.Errorf("iteration did not stop on break, got %d", n)
+	}
+
+	if n := countEmbeddings(complete(5), complete(4), MatchOptions[int, int]{}); n != 0 {
+		t.Errorf("larger pattern found %d times, want 0", n)
+	}
+}
+
+func BenchmarkIsomorphic(b *testing.B) {
+	r := rand.New(rand.NewPCG(1, 1))
+	g1 := New[int](false)
+	g2 := New[int](false)
+	perm := r.Perm(100)
+	for i := 0; i < 300; i++ {
+		u, v := r.IntN(100), r.IntN(100)
+		g1.AddEdge(u, v, 1)
+		g2.AddEdge(perm[u], perm[v], 1)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Isomorphic(g1, g2, MatchOptions[int, int]{})
+	}
+}
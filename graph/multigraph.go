@@ -0,0 +1,226 @@
+package graph
+
+import "slices"
+
+// EdgeID identifies an edge of a MultiGraph. IDs are assigned in insertion
+// order and are never reused by the same graph.
+type EdgeID int
+
+// Edge is a weighted edge from From to To.
+type Edge[T comparable] struct {
+	From, To T
+	Weight   float64
+}
+
+// MultiGraph is an adjacency-list graph that allows parallel edges and
+// self-loops. Every edge has its own EdgeID and weight.
+type MultiGraph[T comparable] struct {
+	directed bool
+	nextID   EdgeID
+	edges    map[EdgeID]Edge[T]
+	adj      map[T]map[EdgeID]struct{} // edges leaving (or, if undirected, touching) a vertex
+	order    []T                       // vertices in insertion order
+}
+
+// NewMulti creates a new multigraph. If directed is true, edges are one-way.
+func NewMulti[T comparable](directed bool) *MultiGraph[T] {
+	return &MultiGraph[T]{
+		directed: directed,
+		edges:    make(map[EdgeID]Edge[T]),
+		adj:      make(map[T]map[EdgeID]struct{}),
+	}
+}
+
+// Directed reports whether edges are one-way.
+func (g *MultiGraph[T]) Directed() bool { return g.directed }
+
+// AddVertex ensures the vertex exists.
+func (g *MultiGraph[T]) AddVertex(v T) {
+	if _, ok := g.adj[v]; !ok {
+		g.adj[v] = make(map[EdgeID]struct{})
+		g.order = append(g.order, v)
+	}
+}
+
+// HasVertex reports whether v is in the graph.
+func (g *MultiGraph[T]) HasVertex(v T) bool {
+	_, ok := g.adj[v]
+	return ok
+}
+
+// AddEdge adds a new edge u->v with weight w and returns its ID.
+// Existing edges between u and v are kept.
+func (g *MultiGraph[T]) AddEdge(u, v T, w float64) EdgeID {
+	g.AddVertex(u)
+	g.AddVertex(v)
+	id := g.nextID
+	g.nextID++
+	g.edges[id] = Edge[T]{From: u, To: v, Weight: w}
+	g.adj[u][id] = struct{}{}
+	if !g.directed {
+		g.adj[v][id] = struct{}{}
+	}
+	return id
+}
+
+// Edge returns the edge with the given ID.
+func (g *MultiGraph[T]) Edge(id EdgeID) (Edge[T], bool) {
+	e, ok := g.edges[id]
+	return e, ok
+}
+
+// RemoveEdge removes the edge with the given ID. Returns true if it existed.
+func (g *MultiGraph[T]) RemoveEdge(id EdgeID) bool {
+	e, ok := g.edges[id]
+	if !ok {
+		return false
+	}
+	delete(g.edges, id)
+	delete(g.adj[e.From], id)
+	if !g.directed {
+		delete(g.adj[e.To], id)
+	}
+	return true
+}
+
+// RemoveVertex removes a vertex and all edges connected to it.
+func (g *MultiGraph[T]) RemoveVertex(v T) {
+	if _, ok := g.adj[v]; !ok {
+		return
+	}
+	for id, e := range g.edges {
+		if e.From == v || e.To == v {
+			g.RemoveEdge(id)
+		}
+	}
+	delete(g.adj, v)
+	i := slices.Index(g.order, v)
+	g.order = slices.Delete(g.order, i, i+1)
+}
+
+// Vertices returns all vertices in the order they were added.
+func (g *MultiGraph[T]) Vertices() []T {
+	return slices.Clone(g.order)
+}
+
+// Order returns the number of vertices.
+func (g *MultiGraph[T]) Order() int { return len(g.adj) }
+
+// Size returns the number of edges. Parallel edges are counted separately
+// and an undirected edge is counted once.
+func (g *MultiGraph[T]) Size() int { return len(g.edges) }
+
+// Edges returns the IDs of all edges in ascending order.
+func (g *MultiGraph[T]) Edges() []EdgeID {
+	out := make([]EdgeID, 0, len(g.edges))
+	for id := range g.edges {
+		out = append(out, id)
+	}
+	slices.Sort(out)
+	return out
+}
+
+// OutEdges returns the IDs of the edges leaving v in ascending order.
+// For undirected graphs this is every edge touching v.
+func (g *MultiGraph[T]) OutEdges(v T) []EdgeID {
+	out := make([]EdgeID, 0, len(g.adj[v]))
+	for id := range g.adj[v] {
+		out = append(out, id)
+	}
+	slices.Sort(out)
+	return out
+}
+
+// EdgesBetween returns the IDs of all edges from u to v in ascending order.
+// For undirected graphs edges in either orientation are included.
+func (g *MultiGraph[T]) EdgesBetween(u, v T) []EdgeID {
+	var out []EdgeID
+	for id := range g.adj[u] {
+		if g.other(id, u) == v {
+			out = append(out, id)
+		}
+	}
+	slices.Sort(out)
+	return out
+}
+
+// Degree returns the number of edge endpoints at v. In undirected graphs a
+// self-loop contributes two; in directed graphs this is the out-degree.
+func (g *MultiGraph[T]) Degree(v T) int {
+	d := len(g.adj[v])
+	if !g.directed {
+		for id := range g.adj[v] {
+			if e := g.edges[id]; e.From == e.To {
+				d++
+			}
+		}
+	}
+	return d
+}
+
+// IsSelfLoop reports whether the edge with the given ID starts and ends at
+// the same vertex.
+func (g *MultiGraph[T]) IsSelfLoop(id EdgeID) bool {
+	e, ok := g.edges[id]
+	return ok && e.From == e.To
+}
+
+// other returns the endpoint of edge id opposite to v.
+func (g *MultiGraph[T]) other(id EdgeID, v T) T {
+	e := g.edges[id]
+	if e.From == v {
+		return e.To
+	}
+	return e.From
+}
+
+// Clone returns a deep copy of the multigraph. Edge IDs are preserved.
+func (g *MultiGraph[T]) Clone() *MultiGraph[T] {
+	clone := NewMulti[T](g.directed)
+	clone.nextID = g.nextID
+	clone.order = slices.Clone(g.order)
+	for id, e := range g.edges {
+		clone.edges[id] = e
+	}
+	for v, ids := range g.adj {
+		clone.adj[v] = make(map[EdgeID]struct{}, len(ids))
+		for id := range ids {
+			clone.adj[v][id] = struct{}{}
+		}
+	}
+	return clone
+}
+
+// ToGraph collapses the multigraph into a simple Graph. Weights of parallel
+// edges are combined with merge in ascending edge ID order. If merge is nil
+// the edge with the highest ID wins. Self-loops are kept.
+func (g *MultiGraph[T]) ToGraph(merge func(a, b float64) float64) *Graph[T] {
+	out := New[T](g.directed)
+	for _, v := range g.order {
+		out.AddVertex(v)
+	}
+	for _, id := range g.Edges() {
+		e := g.edges[id]
+		w := e.Weight
+		if old, ok := out.adj[e.From][e.To]; ok && merge != nil {
+			w = merge(old, w)
+		}
+		out.AddEdge(e.From, e.To, w)
+	}
+	return out
+}
+
+// FromGraph builds a multigraph with one edge for every edge of g.
+// Vertices keep their order, and edge IDs follow the order of g.Edges(),
+// so the result is the same on every run. For undirected graphs each edge
+// is added once.
+func FromGraph[T comparable](g *Graph[T]) *MultiGraph[T] {
+	mg := NewMulti[T](g.directed)
+	for _, v := range g.order {
+		mg.AddVertex(v)
+	}
+	for _, e := range g.Edges() {
+		mg.AddEdge(e.From, e.To, e.Weight)
+	}
+	return mg
+}
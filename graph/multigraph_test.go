@@ -0,0 +1,262 @@
+package graph
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestMultiGraphParallelEdges(t *testing.T) {
+	g := NewMulti[string](true)
+
+	rail := g.AddEdge("Paris", "Lyon", 120)
+	road := g.AddEdge("Paris", "Lyon", 280)
+	g.AddEdge("Lyon", "Paris", 120)
+
+	if rail == road {
+		t.Fatal("parallel edges should get distinct IDs")
+	}
+	if g.Size() != 3 {
+		t.Errorf("size = %d, want 3", g.Size())
+	}
+	if g.Order() != 2 {
+		t.Errorf("order = %d, want 2", g.Order())
+	}
+
+	got := g.EdgesBetween("Paris", "Lyon")
+	if !slices.Equal(got, []EdgeID{rail, road}) {
+		t.Errorf("EdgesBetween = %v, want %v", got, []EdgeID{rail, road})
+	}
+
+	e, ok := g.Edge(road)
+	if !ok || e.From != "Paris" || e.To != "Lyon" || e.Weight != 280 {
+		t.Errorf("Edge(%d) = %+v, %v", road, e, ok)
+	}
+}
+
+func TestMultiGraphUndirected(t *testing.T) {
+	g := NewMulti[int](false)
+
+	a := g.AddEdge(1, 2, 1)
+	b := g.AddEdge(2, 1, 2)
+
+	if got := g.EdgesBetween(1, 2); !slices.Equal(got, []EdgeID{a, b}) {
+		t.Errorf("EdgesBetween(1, 2) = %v, want %v", got, []EdgeID{a, b})
+	}
+	if got := g.EdgesBetween(2, 1); !slices.Equal(got, []EdgeID{a, b}) {
+		t.Errorf("EdgesBetween(2, 1) = %v, want %v", got, []EdgeID{a, b})
+	}
+	if g.Degree(1) != 2 || g.Degree(2) != 2 {
+		t.Errorf("degrees = %d, %d, want 2, 2", g.Degree(1), g.Degree(2))
+	}
+}
+
+func TestMultiGraphSelfLoop(t *testing.T) {
+	t.Run("undirected", func(t *testing.T) {
+		g := NewMulti[int](false)
+		loop := g.AddEdge(1, 1, 5)
+		g.AddEdge(1, 2, 1)
+
+		if !g.IsSelfLoop(loop) {
+			t.Error("edge should be a self-loop")
+		}
+		if g.Degree(1) != 3 {
+			t.Errorf("degree = %d, want 3", g.Degree(1))
+		}
+		if got := g.EdgesBetween(1, 1); !slices.Equal(got, []EdgeID{loop}) {
+			t.Errorf("EdgesBetween(1, 1) = %v, want [%d]", got, loop)
+		}
+	})
+
+	t.Run("directed", func(t *testing.T) {
+		g := NewMulti[int](true)
+		g.AddEdge(1, 1, 5)
+		g.AddEdge(1, 1, 6)
+
+		if g.Degree(1) != 2 {
+			t.Errorf("degree = %d, want 2", g.Degree(1))
+		}
+	})
+}
+
+func TestMultiGraphRemoveEdge(t *testing.T) {
+	g := NewMulti[int](false)
+	a := g.AddEdge(1, 2, 1)
+	b := g.AddEdge(1, 2, 2)
+
+	if !g.RemoveEdge(a) {
+		t.Fatal("RemoveEdge should report existing edge")
+	}
+	if g.RemoveEdge(a) {
+		t.Error("RemoveEdge twice should return false")
+	}
+	if got := g.EdgesBetween(2, 1); !slices.Equal(got, []EdgeID{b}) {
+		t.Errorf("EdgesBetween = %v, want [%d]", got, b)
+	}
+	if _, ok := g.Edge(a); ok {
+		t.Error("removed edge should not be found")
+	}
+
+	c := g.AddEdge(1, 2, 3)
+	if c == a {
+		t.Error("edge IDs should not be reused")
+	}
+}
+
+func TestMultiGraphRemoveVertex(t *testing.T) {
+	g := NewMulti[int](true)
+	g.AddEdge(1, 2, 1)
+	g.AddEdge(3, 2, 1)
+	g.AddEdge(2, 2, 1)
+	keep := g.AddEdge(1, 3, 1)
+
+	g.RemoveVertex(2)
+
+	if g.HasVertex(2) {
+		t.Error("vertex 2 should be removed")
+	}
+	if !slices.Equal(g.Edges(), []EdgeID{keep}) {
+		t.Errorf("edges = %v, want [%d]", g.Edges(), keep)
+	}
+	if !slices.Equal(g.OutEdges(3), []EdgeID{}) {
+		t.Errorf("OutEdges(3) = %v, want none", g.OutEdges(3))
+	}
+
+	// Removing a missing vertex is a no-op
+	g.RemoveVertex(42)
+}
+
+func TestMultiGraphToGraph(t *testing.T) {
+	g := NewMulti[string](true)
+	g.AddEdge("A", "B", 3)
+	g.AddEdge("A", "B", 1)
+	g.AddEdge("A", "B", 2)
+	g.AddEdge("B", "B", 7)
+	g.AddVertex("C")
+
+	t.Run("min", func(t *testing.T) {
+		sg := g.ToGraph(func(a, b float64) float64 { return min(a, b) })
+		if w := sg.Neighbors("A")["B"]; w != 1 {
+			t.Errorf("A->B = %v, want 1", w)
+		}
+		if w, ok := sg.Neighbors("B")["B"]; !ok || w != 7 {
+			t.Errorf("self-loop B->B = %v, %v, want 7", w, ok)
+		}
+		if len(sg.Vertices()) != 3 {
+			t.Errorf("vertex count = %d, want 3", len(sg.Vertices()))
+		}
+	})
+
+	t.Run("sum", func(t *testing.T) {
+		sg := g.ToGraph(func(a, b float64) float64 { return a + b })
+		if w := sg.Neighbors("A")["B"]; w != 6 {
+			t.Errorf("A->B = %v, want 6", w)
+		}
+	})
+
+	t.Run("nil merge keeps last", func(t *testing.T) {
+		sg := g.ToGraph(nil)
+		if w := sg.Neighbors("A")["B"]; w != 2 {
+			t.Errorf("A->B = %v, want 2", w)
+		}
+	})
+}
+
+func TestMultiGraphFromGraph(t *testing.T) {
+	t.Run("undirected", func(t *testing.T) {
+		g := New[int](false)
+		g.AddEdge(1, 2, 1)
+		g.AddEdge(2, 3, 2)
+		g.AddEdge(3, 3, 4)
+		g.AddVertex(9)
+
+		mg := FromGraph(g)
+		if mg.Size() != 3 {
+			t.Errorf("size = %d, want 3", mg.Size())
+		}
+		if mg.Order() != 4 {
+			t.Errorf("order = %d, want 4", mg.Order())
+		}
+		if len(mg.EdgesBetween(2, 1)) != 1 {
+			t.Error("edge 1-2 should appear exactly once")
+		}
+	})
+
+	t.Run("directed round trip", func(t *testing.T) {
+		g := New[int](true)
+		g.AddEdge(1, 2, 1)
+		g.AddEdge(2, 1, 5)
+
+		back := FromGraph(g).ToGraph(nil)
+		if back.Neighbors(1)[2] != 1 || back.Neighbors(2)[1] != 5 {
+			t.Error("round trip should preserve edges and weights")
+		}
+	})
+
+	t.Run("stable order", func(t *testing.T) {
+		g := New[string](true)
+		g.AddEdge("c", "a", 1)
+		g.AddEdge("c", "b", 2)
+		g.AddEdge("a", "b", 3)
+		g.AddEdge("b", "c", 4)
+		g.AddVertex("d")
+
+		mg := FromGraph(g)
+		if got, want := mg.Vertices(), []string{"c", "a", "b", "d"}; !slices.Equal(got, want) {
+			t.Errorf("vertices = %v, want %v", got, want)
+		}
+		want := []Edge[string]{{"c", "a", 1}, {"c", "b", 2}, {"a", "b", 3}, {"b", "c", 4}}
+		for i, id := range mg.Edges() {
+			if e, _ := mg.Edge(id); e != want[i] {
+				t.Errorf("edge %d = %v, want %v", id, e, want[i])
+			}
+		}
+		if got := mg.ToGraph(nil).Vertices(); !slices.Equal(got, mg.Vertices()) {
+			t.Errorf("ToGraph vertices = %v, want %v", got, mg.Vertices())
+		}
+	})
+}
+
+func TestMultiGraphVerticesOrder(t *testing.T) {
+	g := NewMulti[int](true)
+	g.AddEdge(3, 1, 1)
+	g.AddVertex(2)
+	g.AddEdge(1, 4, 1)
+	g.RemoveVertex(1)
+	g.AddVertex(1)
+
+	want := []int{3, 2, 4, 1}
+	if got := g.Vertices(); !slices.Equal(got, want) {
+		t.Errorf("vertices = %v, want %v", got, want)
+	}
+	clone := g.Clone()
+	clone.AddVertex(5)
+	if got := g.Vertices(); !slices.Equal(got, want) {
+		t.Errorf("after adding to clone, vertices = %v, want %v", got, want)
+	}
+}
+
+func TestMultiGraphClone(t *testing.T) {
+	g := NewMulti[int](false)
+	id := g.AddEdge(1, 2, 1)
+	clone := g.Clone()
+
+	clone.RemoveEdge(id)
+	clone.AddEdge(1, 2, 2)
+
+	if _, ok := g.Edge(id); !ok {
+		t.Error("modifying clone should not affect original")
+	}
+	if g.Size() != 1 {
+		t.Errorf("original size = %d, want 1", g.Size())
+	}
+	if next := clone.Edges()[0]; next == id {
+		t.Error("clone should continue the original ID sequence")
+	}
+}
+
+func BenchmarkMultiGraphAddEdge(b *testing.B) {
+	g := NewMulti[int](true)
+	for i := 0; i < b.N; i++ {
+		g.AddEdge(i%1000, (i+1)%1000, float64(i))
+	}
+}
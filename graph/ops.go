@@ -0,0 +1,184 @@
+package graph
+
+import "slices"
+
+// Edges returns every edge of the graph, ordered by the insertion order of
+// their source and then of their target vertex. Each undirected edge is
+// listed once, from the endpoint that was added first.
+func (g *Graph[T]) Edges() []Edge[T] {
+	index := make(map[T]int, len(g.order))
+	for i, v := range g.order {
+		index[v] = i
+	}
+	var out []Edge[T]
+	for i, u := range g.order {
+		start := len(out)
+		for v, w := range g.adj[u] {
+			if g.directed || index[v] >= i {
+				out = append(out, Edge[T]{From: u, To: v, Weight: w})
+			}
+		}
+		slices.SortFunc(out[start:], func(a, b Edge[T]) int { return index[a.To] - index[b.To] })
+	}
+	return out
+}
+
+// Filter returns a new graph with the vertices accepted by keepVertex and
+// the edges between them accepted by keepEdge. A nil predicate accepts
+// everything. keepEdge is called once per undirected edge.
+func (g *Graph[T]) Filter(keepVertex func(v T) bool, keepEdge func(u, v T, w float64) bool) *Graph[T] {
+	out := New[T](g.directed)
+	for _, v := range g.order {
+		if keepVertex == nil || keepVertex(v) {
+			out.AddVertex(v)
+		}
+	}
+	for _, e := range g.Edges() {
+		if !out.HasVertex(e.From) || !out.HasVertex(e.To) {
+			continue
+		}
+		if keepEdge == nil || keepEdge(e.From, e.To, e.Weight) {
+			out.AddEdge(e.From, e.To, e.Weight)
+		}
+	}
+	return out
+}
+
+// Subgraph returns a new graph with the given vertices and every edge
+// touching them, including the vertices at the far end of those edges.
+// Vertices not in g are ignored.
+func (g *Graph[T]) Subgraph(vertices []T) *Graph[T] {
+	keep := make(map[T]bool, len(vertices))
+	reach := make(map[T]bool, len(vertices))
+	for _, v := range vertices {
+		if g.HasVertex(v) {
+			keep[v] = true
+			reach[v] = true
+		}
+	}
+	for u, neighbors := range g.adj {
+		for n := range neighbors {
+			if keep[u] || keep[n] {
+				reach[u] = true
+				reach[n] = true
+			}
+		}
+	}
+	return g.Filter(
+		func(v T) bool { return reach[v] },
+		func(u, v T, _ float64) bool { return keep[u] || keep[v] },
+	)
+}
+
+// InducedSubgraph returns a new graph with the given vertices and only the
+// edges whose endpoints are both among them. Vertices not in g are ignored.
+func (g *Graph[T]) InducedSubgraph(vertices []T) *Graph[T] {
+	keep := make(map[T]bool, len(vertices))
+	for _, v := range vertices {
+		keep[v] = true
+	}
+	return g.Filter(func(v T) bool { return keep[v] }, nil)
+}
+
+// Reverse returns the transpose of a directed graph, with every edge u->v
+// replaced by v->u. For undirected graphs it returns a copy.
+func (g *Graph[T]) Reverse() *Graph[T] {
+	if !g.directed {
+		return g.Clone()
+	}
+	out := New[T](true)
+	for _, v := range g.order {
+		out.AddVertex(v)
+	}
+	for _, e := range g.Edges() {
+		out.AddEdge(e.To, e.From, e.Weight)
+	}
+	return out
+}
+
+// Union returns a new graph with the vertices and edges of both a and b.
+// Where both graphs have an edge, the weight from b is used. It panics if
+// one graph is directed and the other is not.
+func Union[T comparable](a, b *Graph[T]) *Graph[T] {
+	mustMatch(a, b)
+	res := a.Clone()
+	for _, v := range b.order {
+		res.AddVertex(v)
+	}
+	for _, e := range b.Edges() {
+		res.AddEdge(e.From, e.To, e.Weight)
+	}
+	return res
+}
+
+// Intersection returns a new graph with the vertices and edges that a and
+// b have in common, keeping the weights from a. It panics if one graph is
+// directed and the other is not.
+func Intersection[T comparable](a, b *Graph[T]) *Graph[T] {
+	mustMatch(a, b)
+	return a.Filter(b.HasVertex, func(u, v T, _ float64) bool {
+		_, ok := b.adj[u][v]
+		return ok
+	})
+}
+
+func mustMatch[T comparable](a, b *Graph[T]) {
+	if a.directed != b.directed {
+		panic("graph: cannot combine directed and undirected graphs")
+	}
+}
+
+// WeightChange describes an edge whose weight differs between two graphs.
+type WeightChange[T comparable] struct {
+	From, To T
+	Old, New float64
+}
+
+// GraphDiff lists the differences between two versions of a graph.
+type GraphDiff[T comparable] struct {
+	AddedVertices   []T
+	RemovedVertices []T
+	AddedEdges      []Edge[T]
+	RemovedEdges    []Edge[T]
+	ChangedEdges    []WeightChange[T]
+}
+
+// IsEmpty reports whether the two graphs were identical.
+func (d GraphDiff[T]) IsEmpty() bool {
+	return len(d.AddedVertices) == 0 && len(d.RemovedVertices) == 0 &&
+		len(d.AddedEdges) == 0 && len(d.RemovedEdges) == 0 && len(d.ChangedEdges) == 0
+}
+
+// Diff returns the changes that turn before into after. Added vertices and
+// edges are listed in the order of after, removed ones in the order of
+// before, as with Edges. Edges removed along with a vertex are reported
+// too. It panics if one graph is directed and the other is not.
+func Diff[T comparable](before, after *Graph[T]) GraphDiff[T] {
+	mustMatch(before, after)
+	var d GraphDiff[T]
+	for _, v := range after.order {
+		if !before.HasVertex(v) {
+			d.AddedVertices = append(d.AddedVertices, v)
+		}
+	}
+	for _, v := range before.order {
+		if !after.HasVertex(v) {
+			d.RemovedVertices = append(d.RemovedVertices, v)
+		}
+	}
+	for _, e := range after.Edges() {
+		old, ok := before.adj[e.From][e.To]
+		switch {
+		case !ok:
+			d.AddedEdges = append(d.AddedEdges, e)
+		case old != e.Weight:
+			d.ChangedEdges = append(d.ChangedEdges, WeightChange[T]{From: e.From, To: e.To, Old: old, New: e.Weight})
+		}
+	}
+	for _, e := range before.Edges() {
+		if _, ok := after.adj[e.From][e.To]; !ok {
+			d.RemovedEdges = append(d.RemovedEdges, e)
+		}
+	}
+	return d
+}
@@ -0,0 +1,217 @@
+package graph
+
+import (
+	"slices"
+	"testing"
+)
+
+// depGraph is a small package dependency graph: edges point from a package
+// to the packages it imports.
+func depGraph() *Graph[string] {
+	g := New[string](true)
+	g.AddEdge("app", "http", 1)
+	g.AddEdge("app", "log", 1)
+	g.AddEdge("http", "net", 1)
+	g.AddEdge("http", "log", 1)
+	g.AddEdge("net", "os", 1)
+	g.AddVertex("unused")
+	return g
+}
+
+func TestEdges(t *testing.T) {
+	g := New[int](false)
+	g.AddEdge(3, 1, 5)
+	g.AddEdge(1, 2, 1)
+	g.AddEdge(2, 2, 7)
+
+	want := []Edge[int]{{3, 1, 5}, {1, 2, 1}, {2, 2, 7}}
+	if got := g.Edges(); !slices.Equal(got, want) {
+		t.Errorf("Edges() = %v, want %v", got, want)
+	}
+	if got := New[int](true).Edges(); len(got) != 0 {
+		t.Errorf("empty graph has edges %v", got)
+	}
+}
+
+func TestFilter(t *testing.T) {
+	g := depGraph()
+	noLog := g.Filter(func(v string) bool { return v != "log" }, nil)
+	if noLog.HasVertex("log") || len(noLog.Vertices()) != 5 {
+		t.Errorf("vertices = %v, want all but log", noLog.Vertices())
+	}
+	if _, ok := noLog.Neighbors("app")["log"]; ok {
+		t.Error("edges to a filtered vertex should be dropped")
+	}
+
+	calls := 0
+	ug := CycleGraph(4, false)
+	even := ug.Filter(nil, func(u, v int, _ float64) bool {
+		calls++
+		return u%2 == 0
+	})
+	if calls != 4 {
+		t.Errorf("edge predicate called %d times, want once per edge", calls)
+	}
+	if got := len(even.Edges()); got != 3 {
+		t.Errorf("kept %d edges, want 3", got)
+	}
+	if _, ok := even.Neighbors(1)[0]; !ok {
+		t.Error("kept undirected edge should be stored both ways")
+	}
+
+	if !Diff(g, g.Filter(nil, nil)).IsEmpty() {
+		t.Error("Filter(nil, nil) should copy the graph")
+	}
+}
+
+func TestSubgraph(t *testing.T) {
+	g := depGraph()
+	sub := g.Subgraph([]string{"http", "missing"})
+
+	want := []string{"app", "http", "log", "net"}
+	if got := sub.Vertices(); !slices.Equal(got, want) {
+		t.Errorf("vertices = %v, want %v", got, want)
+	}
+	wantEdges := []Edge[string]{{"app", "http", 1}, {"http", "log", 1}, {"http", "net", 1}}
+	if got := sub.Edges(); !slices.Equal(got, wantEdges) {
+		t.Errorf("edges = %v, want %v", got, wantEdges)
+	}
+	if !sub.directed {
+		t.Error("subgraph should stay directed")
+	}
+}
+
+func TestInducedSubgraph(t *testing.T) {
+	g := depGraph()
+	sub := g.InducedSubgraph([]string{"app", "http", "log", "missing"})
+
+	if got, want := sub.Vertices(), []string{"app", "http", "log"}; !slices.Equal(got, want) {
+		t.Errorf("vertices = %v, want %v", got, want)
+	}
+	wantEdges := []Edge[string]{{"app", "http", 1}, {"app", "log", 1}, {"http", "log", 1}}
+	if got := sub.Edges(); !slices.Equal(got, wantEdges) {
+		t.Errorf("edges = %v, want %v", got, wantEdges)
+	}
+
+	// The subgraph is independent of the original.
+	sub.AddEdge("log", "app", 1)
+	if _, ok := g.Neighbors("log")["app"]; ok {
+		t.Error("modifying the subgraph should not change the original")
+	}
+}
+
+func TestReverse(t *testing.T) {
+	g := depGraph()
+	r := g.Reverse()
+
+	if !slices.Equal(r.Vertices(), g.Vertices()) {
+		t.Errorf("vertices = %v, want %v", r.Vertices(), g.Vertices())
+	}
+	for _, e := range g.Edges() {
+		if w, ok := r.Neighbors(e.To)[e.From]; !ok || w != e.Weight {
+			t.Errorf("reverse is missing %s->%s", e.To, e.From)
+		}
+		if _, ok := r.Neighbors(e.From)[e.To]; ok {
+			t.Errorf("reverse still has %s->%s", e.From, e.To)
+		}
+	}
+	// Predecessors in the reverse are successors in the original.
+	for p := range r.Predecessors("http") {
+		if p != "net" && p != "log" {
+			t.Errorf("unexpected predecessor %s of http in reverse", p)
+		}
+	}
+
+	ug := PathGraph(3, false)
+	if !Diff(ug, ug.Reverse()).IsEmpty() {
+		t.Error("reversing an undirected graph should copy it")
+	}
+}
+
+func TestUnionIntersection(t *testing.T) {
+	a := New[int](false)
+	a.AddEdge(1, 2, 1)
+	a.AddEdge(2, 3, 1)
+	b := New[int](false)
+	b.AddEdge(2, 3, 5)
+	b.AddEdge(3, 4, 1)
+	b.AddVertex(9)
+
+	u := Union(a, b)
+	if got, want := u.Vertices(), []int{1, 2, 3, 4, 9}; !slices.Equal(got, want) {
+		t.Errorf("union vertices = %v, want %v", got, want)
+	}
+	if got := len(u.Edges()); got != 3 {
+		t.Errorf("union has %d edges, want 3", got)
+	}
+	if w := u.Neighbors(3)[2]; w != 5 {
+		t.Errorf("union weight of 2-3 = %v, want weight from b", w)
+	}
+
+	in := Intersection(a, b)
+	if got, want := in.Vertices(), []int{2, 3}; !slices.Equal(got, want) {
+		t.Errorf("intersection vertices = %v, want %v", got, want)
+	}
+	if got, want := in.Edges(), []Edge[int]{{2, 3, 1}}; !slices.Equal(got, want) {
+		t.Errorf("intersection edges = %v, want %v", got, want)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("combining directed and undirected graphs should panic")
+		}
+	}()
+	Union(a, New[int](true))
+}
+
+func TestDiff(t *testing.T) {
+	v1 := depGraph()
+	v2 := v1.Clone()
+	v2.RemoveVertex("unused")
+	v2.RemoveEdge("app", "log")
+	v2.AddEdge("net", "os", 2)
+	v2.AddEdge("net", "tls", 1)
+
+	d := Diff(v1, v2)
+	if want := []string{"tls"}; !slices.Equal(d.AddedVertices, want) {
+		t.Errorf("AddedVertices = %v, want %v", d.AddedVertices, want)
+	}
+	if want := []string{"unused"}; !slices.Equal(d.RemovedVertices, want) {
+		t.Errorf("RemovedVertices = %v, want %v", d.RemovedVertices, want)
+	}
+	if want := []Edge[string]{{"net", "tls", 1}}; !slices.Equal(d.AddedEdges, want) {
+		t.Errorf("AddedEdges = %v, want %v", d.AddedEdges, want)
+	}
+	if want := []Edge[string]{{"app", "log", 1}}; !slices.Equal(d.RemovedEdges, want) {
+		t.Errorf("RemovedEdges = %v, want %v", d.RemovedEdges, want)
+	}
+	if want := []WeightChange[string]{{"net", "os", 1, 2}}; !slices.Equal(d.ChangedEdges, want) {
+		t.Errorf("ChangedEdges = %v, want %v", d.ChangedEdges, want)
+	}
+	if d.IsEmpty() {
+		t.Error("diff should not be empty")
+	}
+	if !Diff(v1, v1.Clone()).IsEmpty() {
+		t.Error("diff of a graph with its clone should be empty")
+	}
+}
+
+func TestDiffUndirected(t *testing.T) {
+	before := New[int](false)
+	before.AddEdge(1, 2, 1)
+	before.AddEdge(2, 3, 1)
+
+	// Same edges, added in the opposite direction and order.
+	after := New[int](false)
+	after.AddEdge(3, 2, 1)
+	after.AddEdge(2, 1, 4)
+	after.AddEdge(3, 1, 1)
+
+	d := Diff(before, after)
+	if len(d.AddedEdges) != 1 || len(d.RemovedEdges) != 0 {
+		t.Errorf("added %v, removed %v; want only 3-1 added", d.AddedEdges, d.RemovedEdges)
+	}
+	if len(d.ChangedEdges) != 1 || d.ChangedEdges[0].Old != 1 || d.ChangedEdges[0].New != 4 {
+		t.Errorf("ChangedEdges = %v, want the 1-2 weight change once", d.ChangedEdges)
+	}
+}
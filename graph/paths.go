@@ -0,0 +1,307 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"math"
+	"slices"
+
+	"github.com/goforces/gollection/queue"
+)
+
+// ErrNoPath is returned when the destination cannot be reached from the source.
+var ErrNoPath = errors.New("graph: no path")
+
+// step records how a search reached a vertex.
+type step[T comparable] struct {
+	from T
+	w    float64
+}
+
+// BidirectionalDijkstra returns a cheapest path from src to dst by running
+// Dijkstra's algorithm forwards from src and backwards from dst until the
+// two searches meet. Edge weights must be non-negative. It returns ErrNoPath
+// if dst is unreachable, or ctx.Err() if ctx is done first.
+//
+// For repeated queries on a directed Graph, pass a ReverseIndex built once.
+// Given a plain *Graph, every call first indexes its incoming edges in
+// O(V+E), which costs more than a one-sided search between nearby vertices.
+func BidirectionalDijkstra[T comparable](ctx context.Context, g Reversible[T], src, dst T) (Path[T], error) {
+	type item struct {
+		v    T
+		dist float64
+	}
+	type side struct {
+		dist  map[T]float64
+		steps map[T]step[T]
+		done  map[T]bool
+		pq    *queue.PriorityQueue[item]
+		next  func(T) iter.Seq2[T, float64]
+	}
+	less := func(a, b item) bool { return a.dist < b.dist }
+	newSide := func(start T, next func(T) iter.Seq2[T, float64]) *side {
+		s := &side{
+			dist:  map[T]float64{start: 0},
+			steps: make(map[T]step[T]),
+			done:  make(map[T]bool),
+			pq:    queue.NewPriorityQueue(less),
+			next:  next,
+		}
+		s.pq.Push(item{start, 0})
+		return s
+	}
+
+	if src == dst {
+		return Path[T]{Vertices: []T{src}}, nil
+	}
+
+	fwd := newSide(src, g.Successors)
+	bwd := newSide(dst, predecessors(g))
+	best := math.Inf(1)
+	var meet T
+	for !fwd.pq.IsEmpty() && !bwd.pq.IsEmpty() {
+		if err := ctx.Err(); err != nil {
+			return Path[T]{}, err
+		}
+		f, _ := fwd.pq.Peek()
+		b, _ := bwd.pq.Peek()
+		if f.dist+b.dist >= best {
+			break
+		}
+		cur, other := fwd, bwd
+		if b.dist < f.dist {
+			cur, other = bwd, fwd
+		}
+		top, _ := cur.pq.Pop()
+		if cur.done[top.v] {
+			continue
+		}
+		cur.done[top.v] = true
+		for v, w := range cur.next(top.v) {
+			d := top.dist + w
+			if old, ok := cur.dist[v]; !ok || d < old {
+				cur.dist[v] = d
+				cur.steps[v] = step[T]{top.v, w}
+				cur.pq.Push(item{v, d})
+			}
+			if od, ok := other.dist[v]; ok && cur.dist[v]+od < best {
+				best = cur.dist[v] + od
+				meet = v
+			}
+		}
+	}
+	if math.IsInf(best, 1) {
+		return Path[T]{}, ErrNoPath
+	}
+
+	path := []T{meet}
+	for v := meet; v != src; {
+		v = fwd.steps[v].from
+		path = append(path, v)
+	}
+	slices.Reverse(path)
+	for v := meet; v != dst; {
+		v = bwd.steps[v].from
+		path = append(path, v)
+	}
+	return Path[T]{Vertices: path, Cost: best}, nil
+}
+
+// BidirectionalBFS returns a path from src to dst with the fewest edges by
+// searching breadth-first from both ends. The path's Cost is the sum of its
+// edge weights. It returns ErrNoPath if dst is unreachable, or ctx.Err() if
+// ctx is done first. As with BidirectionalDijkstra, pass a ReverseIndex
+// rather than a directed *Graph for repeated queries.
+func BidirectionalBFS[T comparable](ctx context.Context, g Reversible[T], src, dst T) (Path[T], error) {
+	if src == dst {
+		return Path[T]{Vertices: []T{src}}, nil
+	}
+
+	fwdDepth, bwdDepth := map[T]int{src: 0}, map[T]int{dst: 0}
+	fwdSteps, bwdSteps := make(map[T]step[T]), make(map[T]step[T])
+	fwdFrontier, bwdFrontier := []T{src}, []T{dst}
+	pred := predecessors(g)
+
+	for len(fwdFrontier) > 0 && len(bwdFrontier) > 0 {
+		if err := ctx.Err(); err != nil {
+			return Path[T]{}, err
+		}
+		// Expand the smaller frontier by one full level.
+		forward := len(fwdFrontier) <= len(bwdFrontier)
+		frontier, depth, steps, otherDepth, next := fwdFrontier, fwdDepth, fwdSteps, bwdDepth, g.Successors
+		if !forward {
+			frontier, depth, steps, otherDepth, next = bwdFrontier, bwdDepth, bwdSteps, fwdDepth, pred
+		}
+
+		var level []T
+		var meet T
+		found := false
+		bestLen := math.MaxInt
+		for _, u := range frontier {
+			for v, w := range next(u) {
+				if _, seen := depth[v]; !seen {
+					depth[v] = depth[u] + 1
+					steps[v] = step[T]{u, w}
+					level = append(level, v)
+				}
+				if od, ok := otherDepth[v]; ok && depth[v]+od < bestLen {
+					bestLen = depth[v] + od
+					meet = v
+					found = true
+				}
+			}
+		}
+		if found {
+			return joinPath(meet, src, dst, fwdSteps, bwdSteps), nil
+		}
+		if forward {
+			fwdFrontier = level
+		} else {
+			bwdFrontier = level
+		}
+	}
+	return Path[T]{}, ErrNoPath
+}
+
+// joinPath stitches together the forward and backward halves of a path that
+// meet at meet.
+func joinPath[T comparable](meet, src, dst T, fwd, bwd map[T]step[T]) Path[T] {
+	var cost float64
+	path := []T{meet}
+	for v := meet; v != src; {
+		s := fwd[v]
+		cost += s.w
+		v = s.from
+		path = append(path, v)
+	}
+	slices.Reverse(path)
+	for v := meet; v != dst; {
+		s := bwd[v]
+		cost += s.w
+		v = s.from
+		path = append(path, v)
+	}
+	return Path[T]{Vertices: path, Cost: cost}
+}
+
+// KShortestPaths returns up to k loopless paths from src to dst in order of
+// increasing cost using Yen's algorithm. Edge weights must be non-negative.
+// It returns ErrNoPath if dst is unreachable, or ctx.Err() if ctx is done
+// first.
+func KShortestPaths[T comparable](ctx context.Context, g Interface[T], src, dst T, k int) ([]Path[T], error) {
+	if k <= 0 {
+		return nil, nil
+	}
+
+	first, err := prefixPath(ctx, g, src, dst)
+	if err != nil {
+		return nil, err
+	}
+	accepted := []yenPath[T]{first}
+	var candidates []yenPath[T]
+
+	for len(accepted) < k {
+		last := accepted[len(accepted)-1]
+		for i := 0; i < len(last.vertices)-1; i++ {
+			spur := last.vertices[i]
+			root := last.vertices[:i+1]
+
+			view := &excluding[T]{
+				g:        g,
+				vertices: make(map[T]bool, i),
+				edges:    make(map[[2]T]bool),
+			}
+			for _, v := range root[:i] {
+				view.vertices[v] = true
+			}
+			for _, p := range accepted {
+				if len(p.vertices) > i+1 && slices.Equal(p.vertices[:i+1], root) {
+					view.edges[[2]T{p.vertices[i], p.vertices[i+1]}] = true
+				}
+			}
+
+			tail, err := prefixPath(ctx, view, spur, dst)
+			if errors.Is(err, ErrNoPath) {
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+
+			cand := yenPath[T]{
+				vertices: append(slices.Clone(root), tail.vertices[1:]...),
+				prefix:   slices.Clone(last.prefix[:i+1]),
+			}
+			for _, c := range tail.prefix[1:] {
+				cand.prefix = append(cand.prefix, last.prefix[i]+c)
+			}
+			if !slices.ContainsFunc(candidates, cand.equal) && !slices.ContainsFunc(accepted, cand.equal) {
+				candidates = append(candidates, cand)
+			}
+		}
+		if len(candidates) == 0 {
+			break
+		}
+		best := 0
+		for j, c := range candidates {
+			if c.cost() < candidates[best].cost() {
+				best = j
+			}
+		}
+		accepted = append(accepted, candidates[best])
+		candidates = slices.Delete(candidates, best, best+1)
+	}
+
+	out := make([]Path[T], len(accepted))
+	for i, p := range accepted {
+		out[i] = Path[T]{Vertices: p.vertices, Cost: p.cost()}
+	}
+	return out, nil
+}
+
+// yenPath is a path together with the cost of reaching each of its vertices.
+type yenPath[T comparable] struct {
+	vertices []T
+	prefix   []float64
+}
+
+func (p yenPath[T]) cost() float64 { return p.prefix[len(p.prefix)-1] }
+
+func (p yenPath[T]) equal(q yenPath[T]) bool { return slices.Equal(p.vertices, q.vertices) }
+
+// prefixPath finds a cheapest path from src to dst along with its prefix costs.
+func prefixPath[T comparable](ctx context.Context, g Interface[T], src, dst T) (yenPath[T], error) {
+	dist, prev, found, err := search(ctx, g, src, &dst, nil)
+	if err != nil {
+		return yenPath[T]{}, err
+	}
+	if !found {
+		return yenPath[T]{}, ErrNoPath
+	}
+	p := yenPath[T]{vertices: walkBack(prev, src, dst)}
+	for _, v := range p.vertices {
+		p.prefix = append(p.prefix, dist[v])
+	}
+	return p, nil
+}
+
+// excluding hides some vertices and edges of an underlying graph.
+type excluding[T comparable] struct {
+	g        Interface[T]
+	vertices map[T]bool
+	edges    map[[2]T]bool
+}
+
+func (x *excluding[T]) Successors(v T) iter.Seq2[T, float64] {
+	return func(yield func(T, float64) bool) {
+		for n, w := range x.g.Successors(v) {
+			if x.vertices[n] || x.edges[[2]T{v, n}] {
+				continue
+			}
+			if !yield(n, w) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,272 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+// yenExample is the directed graph from the usual presentation of Yen's algorithm.
+func yenExample() *Graph[string] {
+	g := New[string](true)
+	g.AddEdge("C", "D", 3)
+	g.AddEdge("C", "E", 2)
+	g.AddEdge("D", "F", 4)
+	g.AddEdge("E", "D", 1)
+	g.AddEdge("E", "F", 2)
+	g.AddEdge("E", "G", 3)
+	g.AddEdge("F", "G", 2)
+	g.AddEdge("F", "H", 1)
+	g.AddEdge("G", "H", 2)
+	return g
+}
+
+func TestBidirectionalDijkstra(t *testing.T) {
+	g := yenExample()
+	p, err := BidirectionalDijkstra(context.Background(), g, "C", "H")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if p.Cost != 5 || !slices.Equal(p.Vertices, []string{"C", "E", "F", "H"}) {
+		t.Errorf("path = %+v, want C-E-F-H cost 5", p)
+	}
+
+	if _, err := BidirectionalDijkstra(context.Background(), g, "H", "C"); !errors.Is(err, ErrNoPath) {
+		t.Errorf("reverse direction err = %v, want ErrNoPath", err)
+	}
+
+	p, err = BidirectionalDijkstra(context.Background(), g, "D", "D")
+	if err != nil || p.Cost != 0 || !slices.Equal(p.Vertices, []string{"D"}) {
+		t.Errorf("trivial path = %+v, %v", p, err)
+	}
+}
+
+func TestBidirectionalMatchesDijkstra(t *testing.T) {
+	r := rand.New(rand.NewPCG(11, 12))
+	for _, directed := range []bool{true, false} {
+		g := New[int](directed)
+		for i := 0; i < 400; i++ {
+			g.AddEdge(r.IntN(100), r.IntN(100), float64(1+r.IntN(20)))
+		}
+		for trial := 0; trial < 50; trial++ {
+			src, dst := r.IntN(100), r.IntN(100)
+			want, ok := ShortestPath[int](g, src, dst)
+			got, err := BidirectionalDijkstra(context.Background(), g, src, dst)
+			if !ok {
+				if !errors.Is(err, ErrNoPath) {
+					t.Errorf("%d->%d: err = %v, want ErrNoPath", src, dst, err)
+				}
+				continue
+			}
+			if err != nil {
+				t.Fatalf("%d->%d: unexpected error %v", src, dst, err)
+			}
+			if got.Cost != want.Cost {
+				t.Errorf("%d->%d: cost = %v, want %v", src, dst, got.Cost, want.Cost)
+			}
+			if pathCost(g, got.Vertices) != got.Cost {
+				t.Errorf("%d->%d: reported cost %v does not match path %v", src, dst, got.Cost, got.Vertices)
+			}
+		}
+	}
+}
+
+func pathCost(g *Graph[int], path []int) float64 {
+	total := 0.0
+	for i := 1; i < len(path); i++ {
+		w, ok := g.Neighbors(path[i-1])[path[i]]
+		if !ok {
+			return -1
+		}
+		total += w
+	}
+	return total
+}
+
+func TestBidirectionalBFS(t *testing.T) {
+	g := yenExample()
+	p, err := BidirectionalBFS(context.Background(), g, "C", "H")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(p.Vertices) != 4 {
+		t.Errorf("path = %v, want 3 edges", p.Vertices)
+	}
+	if p.Vertices[0] != "C" || p.Vertices[3] != "H" {
+		t.Errorf("path = %v, want it to run from C to H", p.Vertices)
+	}
+
+	ring := New[int](false)
+	for i := 0; i < 10; i++ {
+		ring.AddEdge(i, (i+1)%10, 2)
+	}
+	rp, err := BidirectionalBFS(context.Background(), ring, 0, 7)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !slices.Equal(rp.Vertices, []int{0, 9, 8, 7}) || rp.Cost != 6 {
+		t.Errorf("path = %+v, want 0-9-8-7 cost 6", rp)
+	}
+
+	ring.AddVertex(42)
+	if _, err := BidirectionalBFS(context.Background(), ring, 0, 42); !errors.Is(err, ErrNoPath) {
+		t.Errorf("err = %v, want ErrNoPath", err)
+	}
+}
+
+func TestBidirectionalBFSMatchesBFS(t *testing.T) {
+	r := rand.New(rand.NewPCG(21, 22))
+	g := New[int](true)
+	for i := 0; i < 300; i++ {
+		g.AddEdge(r.IntN(100), r.IntN(100), 1)
+	}
+	for trial := 0; trial < 50; trial++ {
+		src, dst := r.IntN(100), r.IntN(100)
+		want := -1
+		BFS[int](g, src, func(v, depth int) bool {
+			if v == dst {
+				want = depth
+				return false
+			}
+			return true
+		})
+		got, err := BidirectionalBFS(context.Background(), g, src, dst)
+		if want < 0 {
+			if !errors.Is(err, ErrNoPath) {
+				t.Errorf("%d->%d: err = %v, want ErrNoPath", src, dst, err)
+			}
+			continue
+		}
+		if err != nil || len(got.Vertices)-1 != want || pathCost(g, got.Vertices) < 0 {
+			t.Errorf("%d->%d: path %v, err %v, want %d edges", src, dst, got.Vertices, err, want)
+		}
+	}
+}
+
+func TestKShortestPaths(t *testing.T) {
+	g := yenExample()
+	paths, err := KShortestPaths[string](context.Background(), g, "C", "H", 3)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []Path[string]{
+		{Vertices: []string{"C", "E", "F", "H"}, Cost: 5},
+		{Vertices: []string{"C", "E", "G", "H"}, Cost: 7},
+		{Vertices: []string{"C", "D", "F", "H"}, Cost: 8},
+	}
+	if len(paths) != len(want) {
+		t.Fatalf("got %d paths, want %d: %v", len(paths), len(want), paths)
+	}
+	for i := range want {
+		if paths[i].Cost != want[i].Cost || !slices.Equal(paths[i].Vertices, want[i].Vertices) {
+			t.Errorf("path %d = %+v, want %+v", i, paths[i], want[i])
+		}
+	}
+}
+
+func TestKShortestPathsExhausted(t *testing.T) {
+	g := New[int](true)
+	g.AddEdge(1, 2, 1)
+	g.AddEdge(2, 3, 1)
+	g.AddEdge(1, 3, 5)
+
+	paths, err := KShortestPaths[int](context.Background(), g, 1, 3, 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(paths) != 2 {
+		t.Fatalf("got %d paths, want 2: %v", len(paths), paths)
+	}
+	if paths[0].Cost != 2 || paths[1].Cost != 5 {
+		t.Errorf("costs = %v, %v, want 2, 5", paths[0].Cost, paths[1].Cost)
+	}
+
+	if paths, err := KShortestPaths[int](context.Background(), g, 1, 3, 0); paths != nil || err != nil {
+		t.Errorf("k=0 should return nothing, got %v, %v", paths, err)
+	}
+	if _, err := KShortestPaths[int](context.Background(), g, 3, 1, 2); !errors.Is(err, ErrNoPath) {
+		t.Errorf("err = %v, want ErrNoPath", err)
+	}
+}
+
+func TestKShortestPathsLoopless(t *testing.T) {
+	g := New[int](false)
+	for i := 0; i < 5; i++ {
+		for j := i + 1; j < 5; j++ {
+			g.AddEdge(i, j, float64(1+i+j))
+		}
+	}
+	paths, err := KShortestPaths[int](context.Background(), g, 0, 4, 20)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// K5 has 1 + 3 + 6 + 6 = 16 simple paths between two vertices.
+	if len(paths) != 16 {
+		t.Errorf("got %d paths, want 16", len(paths))
+	}
+	for i, p := range paths {
+		seen := make(map[int]bool)
+		for _, v := range p.Vertices {
+			if seen[v] {
+				t.Errorf("path %v repeats vertex %d", p.Vertices, v)
+			}
+			seen[v] = true
+		}
+		if i > 0 && p.Cost < paths[i-1].Cost {
+			t.Errorf("paths not sorted by cost at %d", i)
+		}
+	}
+}
+
+func TestPathsContextCancelled(t *testing.T) {
+	g := yenExample()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := BidirectionalDijkstra(ctx, g, "C", "H"); !errors.Is(err, context.Canceled) {
+		t.Errorf("BidirectionalDijkstra err = %v, want context.Canceled", err)
+	}
+	if _, err := BidirectionalBFS(ctx, g, "C", "H"); !errors.Is(err, context.Canceled) {
+		t.Errorf("BidirectionalBFS err = %v, want context.Canceled", err)
+	}
+	if _, err := KShortestPaths[string](ctx, g, "C", "H", 3); !errors.Is(err, context.Canceled) {
+		t.Errorf("KShortestPaths err = %v, want context.Canceled", err)
+	}
+}
+
+func BenchmarkBidirectionalDijkstra(b *testing.B) {
+	const n = 100000
+	g := New[int](true)
+	for i := 0; i < n; i++ {
+		g.AddEdge(i, (i+1)%n, 1)
+		g.AddEdge(i, (i*7)%n, 3)
+	}
+	idx := NewReverseIndex(g)
+	defer idx.Close()
+	ctx := context.Background()
+
+	for _, q := range []struct {
+		name     string
+		src, dst int
+	}{{"near", 10, 12}, {"far", 0, n / 2}} {
+		b.Run(q.name+"/ShortestPath", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				ShortestPath[int](g, q.src, q.dst)
+			}
+		})
+		b.Run(q.name+"/ReverseIndex", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				BidirectionalDijkstra[int](ctx, idx, q.src, q.dst)
+			}
+		})
+		// A plain *Graph is indexed on every call.
+		b.Run(q.name+"/Graph", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				BidirectionalDijkstra(ctx, g, q.src, q.dst)
+			}
+		})
+	}
+}
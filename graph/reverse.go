@@ -0,0 +1,76 @@
+package graph
+
+import "iter"
+
+// ReverseIndex makes a Graph Reversible with Predecessors that run in time
+// proportional to the in-degree of the vertex. Graph stores only outgoing
+// edges, so build the index once and reuse it across queries such as
+// BidirectionalDijkstra. It observes the graph and stays up to date until
+// Close is called.
+//
+// Like Graph, ReverseIndex is not safe for concurrent use.
+type ReverseIndex[T comparable] struct {
+	g      *Graph[T]
+	in     map[T]map[T]float64 // incoming edges; nil for undirected graphs
+	cancel func()
+}
+
+// NewReverseIndex indexes the edges entering each vertex of g in O(V+E)
+// and observes g for changes until Close is called.
+func NewReverseIndex[T comparable](g *Graph[T]) *ReverseIndex[T] {
+	r := &ReverseIndex[T]{g: g, cancel: func() {}}
+	if !g.directed {
+		return r // undirected edges are their own reverse
+	}
+	r.in = make(map[T]map[T]float64, len(g.adj))
+	for _, u := range g.order {
+		for v, w := range g.adj[u] {
+			r.addEdge(u, v, w)
+		}
+	}
+	r.cancel = g.Observe(r.update)
+	return r
+}
+
+// Close stops tracking changes to the graph. The index must not be used
+// after the graph changes again.
+func (r *ReverseIndex[T]) Close() {
+	r.cancel()
+}
+
+// Successors yields the neighbors of v with their edge weights.
+func (r *ReverseIndex[T]) Successors(v T) iter.Seq2[T, float64] {
+	return r.g.Successors(v)
+}
+
+// Predecessors yields the vertices with an edge into v and their weights.
+func (r *ReverseIndex[T]) Predecessors(v T) iter.Seq2[T, float64] {
+	if !r.g.directed {
+		return r.g.Successors(v)
+	}
+	return func(yield func(T, float64) bool) {
+		for u, w := range r.in[v] {
+			if !yield(u, w) {
+				return
+			}
+		}
+	}
+}
+
+func (r *ReverseIndex[T]) update(e Event[T]) {
+	switch e.Kind {
+	case EdgeAdded, EdgeUpdated:
+		r.addEdge(e.Edge.From, e.Edge.To, e.Edge.Weight)
+	case EdgeRemoved:
+		delete(r.in[e.Edge.To], e.Edge.From)
+	case VertexRemoved:
+		delete(r.in, e.Vertex)
+	}
+}
+
+func (r *ReverseIndex[T]) addEdge(u, v T, w float64) {
+	if r.in[v] == nil {
+		r.in[v] = make(map[T]float64)
+	}
+	r.in[v][u] = w
+}
@@ -0,0 +1,108 @@
+package graph
+
+import (
+	"context"
+	"maps"
+	"math/rand/v2"
+	"testing"
+)
+
+// checkReverseIndex compares every vertex's indexed predecessors with a
+// scan of the graph.
+func checkReverseIndex[T comparable](t *testing.T, r *ReverseIndex[T], g *Graph[T]) {
+	t.Helper()
+	for _, v := range g.Vertices() {
+		got := collectPredecessors[T](r, v)
+		if want := collectPredecessors[T](g, v); !maps.Equal(got, want) {
+			t.Fatalf("Predecessors(%v) = %v, want %v", v, got, want)
+		}
+	}
+}
+
+func TestReverseIndex(t *testing.T) {
+	g := New[string](true)
+	g.AddEdge("a", "b", 1)
+	g.AddEdge("c", "b", 2)
+	r := NewReverseIndex(g)
+	defer r.Close()
+	checkReverseIndex(t, r, g)
+
+	g.AddEdge("b", "d", 3)
+	g.AddEdge("a", "b", 5) // weight update
+	checkReverseIndex(t, r, g)
+	if w := collectPredecessors[string](r, "b")["a"]; w != 5 {
+		t.Errorf("updated weight = %v, want 5", w)
+	}
+
+	g.RemoveEdge("c", "b")
+	g.RemoveVertex("b")
+	checkReverseIndex(t, r, g)
+	if got := collectPredecessors[string](r, "d"); len(got) != 0 {
+		t.Errorf("Predecessors(d) after RemoveVertex = %v, want empty", got)
+	}
+	if got := collectSuccessors[string](r, "a"); len(got) != 0 {
+		t.Errorf("Successors(a) = %v, want empty", got)
+	}
+}
+
+func TestReverseIndexRandom(t *testing.T) {
+	r := rand.New(rand.NewPCG(5, 6))
+	g := New[int](true)
+	idx := NewReverseIndex(g)
+	defer idx.Close()
+	for step := 0; step < 2000; step++ {
+		u, v := r.IntN(30), r.IntN(30)
+		switch r.IntN(4) {
+		case 0, 1:
+			g.AddEdge(u, v, float64(r.IntN(10)))
+		case 2:
+			g.RemoveEdge(u, v)
+		default:
+			g.RemoveVertex(u)
+		}
+	}
+	checkReverseIndex(t, idx, g)
+}
+
+func TestReverseIndexUndirected(t *testing.T) {
+	g := New[int](false)
+	g.AddEdge(1, 2, 4)
+	r := NewReverseIndex(g)
+	defer r.Close()
+	g.AddEdge(2, 3, 1)
+	if got := collectPredecessors[int](r, 2); len(got) != 2 || got[1] != 4 || got[3] != 1 {
+		t.Errorf("Predecessors(2) = %v, want map[1:4 3:1]", got)
+	}
+}
+
+func TestReverseIndexClose(t *testing.T) {
+	g := New[int](true)
+	g.AddEdge(1, 2, 1)
+	r := NewReverseIndex(g)
+	r.Close()
+	g.AddEdge(3, 2, 1)
+	if got := collectPredecessors[int](r, 2); len(got) != 1 {
+		t.Errorf("closed index should stop tracking changes, got %v", got)
+	}
+}
+
+func TestBidirectionalWithReverseIndex(t *testing.T) {
+	g := yenExample()
+	r := NewReverseIndex(g)
+	defer r.Close()
+	ctx := context.Background()
+	for _, src := range g.Vertices() {
+		for _, dst := range g.Vertices() {
+			want, wantErr := BidirectionalDijkstra(ctx, g, src, dst)
+			got, err := BidirectionalDijkstra[string](ctx, r, src, dst)
+			if err != wantErr || got.Cost != want.Cost {
+				t.Errorf("%s->%s: cost %v, %v; want %v, %v", src, dst, got.Cost, err, want.Cost, wantErr)
+			}
+			bfs, err := BidirectionalBFS[string](ctx, r, src, dst)
+			want, wantErr = BidirectionalBFS(ctx, g, src, dst)
+			if err != wantErr || len(bfs.Vertices) != len(want.Vertices) {
+				t.Errorf("%s->%s: BFS path %v, %v; want %v, %v", src, dst, bfs.Vertices, err, want.Vertices, wantErr)
+			}
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package graph
+
+import (
+	"context"
+	"slices"
+
+	"github.com/goforces/gollection/queue"
+)
+
+// Path is a sequence of vertices together with the total weight of the
+// edges between them.
+type Path[T comparable] struct {
+	Vertices []T
+	Cost     float64
+}
+
+// BFS visits vertices reachable from start in breadth-first order, calling
+// visit with each vertex and its depth in edges from start. Traversal stops
+// early when visit returns false, which makes BFS usable on infinite implicit
+// graphs.
+func BFS[T comparable](g Interface[T], start T, visit func(v T, depth int) bool) {
+	depth := map[T]int{start: 0}
+	frontier := []T{start}
+	for head := 0; head < len(frontier); head++ {
+		u := frontier[head]
+		d := depth[u]
+		if !visit(u, d) {
+			return
+		}
+		for v := range g.Successors(u) {
+			if _, seen := depth[v]; !seen {
+				depth[v] = d + 1
+				frontier = append(frontier, v)
+			}
+		}
+	}
+}
+
+// Dijkstra computes the cheapest path cost from src to every vertex
+// reachable from it. prev maps each reached vertex other than src to its
+// predecessor on a cheapest path. Edge weights must be non-negative.
+//
+// Dijkstra explores the whole reachable part of g; use ShortestPath or AStar
+// on infinite implicit graphs.
+func Dijkstra[T comparable](g Interface[T], src T) (dist map[T]float64, prev map[T]T) {
+	dist, prev, _, _ = search(context.Background(), g, src, nil, nil)
+	return dist, prev
+}
+
+// ShortestPath returns a cheapest path from src to dst. The boolean is false
+// when dst is unreachable. Edge weights must be non-negative.
+func ShortestPath[T comparable](g Interface[T], src, dst T) (Path[T], bool) {
+	return AStar(g, src, dst, nil)
+}
+
+// AStar returns a cheapest path from src to dst using heuristic h to guide
+// the search. h(v) must never overestimate the remaining cost from v to dst;
+// a nil h reduces AStar to Dijkstra's algorithm. The boolean is false when
+// dst is unreachable. Edge weights must be non-negative.
+func AStar[T comparable](g Interface[T], src, dst T, h func(T) float64) (Path[T], bool) {
+	dist, prev, found, _ := search(context.Background(), g, src, &dst, h)
+	if !found {
+		return Path[T]{}, false
+	}
+	return Path[T]{Vertices: walkBack(prev, src, dst), Cost: dist[dst]}, true
+}
+
+// search runs A* from src, stopping once dst (if non-nil) is settled or ctx
+// is done.
+func search[T comparable](ctx context.Context, g Interface[T], src T, dst *T, h func(T) float64) (dist map[T]float64, prev map[T]T, found bool, err error) {
+	type item struct {
+		v        T
+		dist     float64
+		priority float64
+	}
+
+	dist = map[T]float64{src: 0}
+	prev = make(map[T]T)
+	done := make(map[T]bool)
+
+	pq := queue.NewPriorityQueue(func(a, b item) bool { return a.priority < b.priority })
+	pq.Push(item{v: src})
+	for !pq.IsEmpty() {
+		if err := ctx.Err(); err != nil {
+			return dist, prev, false, err
+		}
+		cur, _ := pq.Pop()
+		if done[cur.v] || cur.dist > dist[cur.v] {
+			continue // stale entry
+		}
+		done[cur.v] = true
+		if dst != nil && cur.v == *dst {
+			return dist, prev, true, nil
+		}
+		for v, w := range g.Successors(cur.v) {
+			d := cur.dist + w
+			if old, ok := dist[v]; ok && d >= old {
+				continue
+			}
+			dist[v] = d
+			prev[v] = cur.v
+			// A heuristic that never overestimates may still settle v
+			// before its cheapest path is known; reopen it if so.
+			done[v] = false
+			p := d
+			if h != nil {
+				p += h(v)
+			}
+			pq.Push(item{v: v, dist: d, priority: p})
+		}
+	}
+	return dist, prev, false, nil
+}
+
+// walkBack rebuilds the path ending at dst from a predecessor map.
+func walkBack[T comparable](prev map[T]T, src, dst T) []T {
+	path := []T{dst}
+	for v := dst; v != src; {
+		v = prev[v]
+		path = append(path, v)
+	}
+	slices.Reverse(path)
+	return path
+}
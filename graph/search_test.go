@@ -0,0 +1,226 @@
+package graph
+
+import (
+	"iter"
+	"math"
+	"slices"
+	"testing"
+)
+
+type cell struct{ r, c int }
+
+// grid is an implicit 4-connected grid map where '#' marks a wall.
+type grid []string
+
+func (g grid) Successors(p cell) iter.Seq2[cell, float64] {
+	return func(yield func(cell, float64) bool) {
+		for _, d := range []cell{{-1, 0}, {1, 0}, {0, -1}, {0, 1}} {
+			n := cell{p.r + d.r, p.c + d.c}
+			if n.r < 0 || n.r >= len(g) || n.c < 0 || n.c >= len(g[n.r]) || g[n.r][n.c] == '#' {
+				continue
+			}
+			if !yield(n, 1) {
+				return
+			}
+		}
+	}
+}
+
+func manhattan(dst cell) func(cell) float64 {
+	return func(p cell) float64 {
+		return math.Abs(float64(p.r-dst.r)) + math.Abs(float64(p.c-dst.c))
+	}
+}
+
+func TestBFS(t *testing.T) {
+	g := New[int](true)
+	g.AddEdge(0, 1, 1)
+	g.AddEdge(0, 2, 1)
+	g.AddEdge(1, 3, 1)
+	g.AddEdge(2, 3, 1)
+	g.AddEdge(3, 0, 1)
+
+	depths := make(map[int]int)
+	BFS[int](g, 0, func(v, depth int) bool {
+		depths[v] = depth
+		return true
+	})
+
+	want := map[int]int{0: 0, 1: 1, 2: 1, 3: 2}
+	if len(depths) != len(want) {
+		t.Fatalf("visited %v, want %v", depths, want)
+	}
+	for v, d := range want {
+		if depths[v] != d {
+			t.Errorf("depth(%d) = %d, want %d", v, depths[v], d)
+		}
+	}
+}
+
+func TestBFSInfinite(t *testing.T) {
+	// The naturals with edges n -> n+1 and n -> 2n.
+	g := SuccessorsFunc[int](func(v int) iter.Seq2[int, float64] {
+		return func(yield func(int, float64) bool) {
+			_ = yield(v+1, 1) && yield(2*v, 1)
+		}
+	})
+
+	found := -1
+	BFS[int](g, 1, func(v, depth int) bool {
+		if v == 100 {
+			found = depth
+			return false
+		}
+		return true
+	})
+	// 1 -> 2 -> 3 -> 6 -> 12 -> 24 -> 25 -> 50 -> 100
+	if found != 8 {
+		t.Errorf("depth of 100 = %d, want 8", found)
+	}
+}
+
+func TestDijkstra(t *testing.T) {
+	g := New[string](true)
+	g.AddEdge("A", "B", 1)
+	g.AddEdge("A", "C", 4)
+	g.AddEdge("B", "C", 2)
+	g.AddEdge("C", "D", 1)
+	g.AddEdge("B", "D", 5)
+	g.AddVertex("E")
+
+	dist, prev := Dijkstra[string](g, "A")
+
+	want := map[string]float64{"A": 0, "B": 1, "C": 3, "D": 4}
+	if len(dist) != len(want) {
+		t.Errorf("dist = %v, want %v", dist, want)
+	}
+	for v, d := range want {
+		if dist[v] != d {
+			t.Errorf("dist(%s) = %v, want %v", v, dist[v], d)
+		}
+	}
+	if prev["D"] != "C" || prev["C"] != "B" || prev["B"] != "A" {
+		t.Errorf("prev = %v", prev)
+	}
+	if _, ok := prev["A"]; ok {
+		t.Error("source should have no predecessor")
+	}
+}
+
+func TestShortestPath(t *testing.T) {
+	g := New[int](false)
+	g.AddEdge(1, 2, 7)
+	g.AddEdge(1, 3, 9)
+	g.AddEdge(1, 6, 14)
+	g.AddEdge(2, 3, 10)
+	g.AddEdge(2, 4, 15)
+	g.AddEdge(3, 4, 11)
+	g.AddEdge(3, 6, 2)
+	g.AddEdge(4, 5, 6)
+	g.AddEdge(5, 6, 9)
+	g.AddVertex(7)
+
+	for name, impl := range map[string]Interface[int]{"Graph": g, "CSR": g.Freeze()} {
+		t.Run(name, func(t *testing.T) {
+			p, ok := ShortestPath(impl, 1, 5)
+			if !ok {
+				t.Fatal("path 1->5 should exist")
+			}
+			if p.Cost != 20 {
+				t.Errorf("cost = %v, want 20", p.Cost)
+			}
+			if want := []int{1, 3, 6, 5}; !slices.Equal(p.Vertices, want) {
+				t.Errorf("path = %v, want %v", p.Vertices, want)
+			}
+
+			if _, ok := ShortestPath(impl, 1, 7); ok {
+				t.Error("path to isolated vertex should not exist")
+			}
+
+			p, ok = ShortestPath(impl, 4, 4)
+			if !ok || p.Cost != 0 || !slices.Equal(p.Vertices, []int{4}) {
+				t.Errorf("trivial path = %+v, %v", p, ok)
+			}
+		})
+	}
+}
+
+func TestAStarGrid(t *testing.T) {
+	g := grid{
+		".....",
+		".###.",
+		"...#.",
+		".#.#.",
+		".#...",
+	}
+	src, dst := cell{0, 0}, cell{4, 4}
+
+	p, ok := AStar[cell](g, src, dst, manhattan(dst))
+	if !ok {
+		t.Fatal("path should exist")
+	}
+	if p.Cost != 8 {
+		t.Errorf("cost = %v, want 8", p.Cost)
+	}
+	if len(p.Vertices) != 9 || p.Vertices[0] != src || p.Vertices[8] != dst {
+		t.Errorf("path = %v", p.Vertices)
+	}
+
+	// A* with an admissible heuristic must agree with plain Dijkstra.
+	q, _ := ShortestPath[cell](g, src, dst)
+	if q.Cost != p.Cost {
+		t.Errorf("Dijkstra cost = %v, A* cost = %v", q.Cost, p.Cost)
+	}
+
+	walled := grid{
+		".#.",
+		"##.",
+		"...",
+	}
+	if _, ok := AStar[cell](walled, cell{0, 0}, cell{2, 2}, manhattan(cell{2, 2})); ok {
+		t.Error("walled-in source should have no path")
+	}
+}
+
+func TestAStarInconsistentHeuristic(t *testing.T) {
+	// h never overestimates, but h(S) + w(S,B) < h(B), so A is settled
+	// through the direct edge before the cheaper route through B is seen.
+	g := New[string](true)
+	g.AddEdge("S", "A", 3)
+	g.AddEdge("S", "B", 1)
+	g.AddEdge("B", "A", 1)
+	g.AddEdge("A", "D", 3)
+	h := func(v string) float64 {
+		if v == "B" {
+			return 4
+		}
+		return 0
+	}
+
+	p, ok := AStar[string](g, "S", "D", h)
+	if !ok {
+		t.Fatal("path should exist")
+	}
+	if want := []string{"S", "B", "A", "D"}; !slices.Equal(p.Vertices, want) {
+		t.Errorf("path = %v, want %v", p.Vertices, want)
+	}
+	sum := 0.0
+	for i := 1; i < len(p.Vertices); i++ {
+		sum += g.Neighbors(p.Vertices[i-1])[p.Vertices[i]]
+	}
+	if p.Cost != 5 || sum != p.Cost {
+		t.Errorf("cost = %v, edges sum to %v, want 5", p.Cost, sum)
+	}
+}
+
+func BenchmarkShortestPath(b *testing.B) {
+	g := New[int](true)
+	for i := 0; i < 1000; i++ {
+		g.AddEdge(i, (i+1)%1000, 1)
+		g.AddEdge(i, (i*7)%1000, 3)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ShortestPath[int](g, 0, 999)
+	}
+}
@@ -0,0 +1,87 @@
+package graph
+
+import "math"
+
+// NearestNeighborTour builds a travelling salesman tour starting at start by
+// always moving to the cheapest unvisited vertex reachable over a single
+// edge, then returning to start. Ties are broken by insertion order. The
+// returned path visits every vertex once and ends where it began. The
+// boolean is false if the walk gets stuck or cannot close the tour.
+func NearestNeighborTour[T comparable](g *Graph[T], start T) (Path[T], bool) {
+	if _, ok := g.adj[start]; !ok {
+		return Path[T]{}, false
+	}
+	vertices := g.Vertices()
+	visited := map[T]bool{start: true}
+	tour := Path[T]{Vertices: []T{start}}
+	cur := start
+	for len(tour.Vertices) < len(vertices) {
+		best, bestW, found := cur, math.Inf(1), false
+		for _, v := range vertices {
+			if w, ok := g.adj[cur][v]; ok && !visited[v] && w < bestW {
+				best, bestW, found = v, w, true
+			}
+		}
+		if !found {
+			return Path[T]{}, false
+		}
+		visited[best] = true
+		tour.Vertices = append(tour.Vertices, best)
+		tour.Cost += bestW
+		cur = best
+	}
+	w, ok := g.adj[cur][start]
+	if !ok {
+		return Path[T]{}, false
+	}
+	tour.Vertices = append(tour.Vertices, start)
+	tour.Cost += w
+	return tour, true
+}
+
+// TwoOpt improves a closed tour, such as one returned by NearestNeighborTour,
+// by reversing segments while doing so lowers the total cost. Reversals that
+// would need a missing edge are never made. The input is not modified.
+//
+// On directed graphs the cost of every reversed segment is recomputed, so a
+// pass takes O(n³) rather than O(n²) time.
+func TwoOpt[T comparable](g *Graph[T], tour Path[T]) Path[T] {
+	t := make([]T, len(tour.Vertices))
+	copy(t, tour.Vertices)
+	weight := func(u, v T) float64 {
+		if w, ok := g.adj[u][v]; ok {
+			return w
+		}
+		return math.Inf(1)
+	}
+
+	n := len(t) - 1 // t[n] == t[0]
+	for improved := true; improved; {
+		improved = false
+		for i := 1; i < n-1; i++ {
+			for j := i + 1; j < n; j++ {
+				before := weight(t[i-1], t[i]) + weight(t[j], t[j+1])
+				after := weight(t[i-1], t[j]) + weight(t[i], t[j+1])
+				if g.directed {
+					for k := i; k < j; k++ {
+						before += weight(t[k], t[k+1])
+						after += weight(t[k+1], t[k])
+					}
+				}
+				if math.IsInf(after, 1) || after >= before-1e-12 {
+					continue
+				}
+				for a, b := i, j; a < b; a, b = a+1, b-1 {
+					t[a], t[b] = t[b], t[a]
+				}
+				improved = true
+			}
+		}
+	}
+
+	out := Path[T]{Vertices: t}
+	for i := 1; i < len(t); i++ {
+		out.Cost += weight(t[i-1], t[i])
+	}
+	return out
+}
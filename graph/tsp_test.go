@@ -0,0 +1,141 @@
+package graph
+
+import (
+	"math"
+	"math/rand/v2"
+	"testing"
+)
+
+type point struct{ x, y float64 }
+
+func completeEuclidean(points []point) *Graph[int] {
+	g := New[int](false)
+	for i := range points {
+		for j := i + 1; j < len(points); j++ {
+			g.AddEdge(i, j, math.Hypot(points[i].x-points[j].x, points[i].y-points[j].y))
+		}
+	}
+	return g
+}
+
+func checkTour[T comparable](t *testing.T, g *Graph[T], tour Path[T], start T) {
+	t.Helper()
+	n := len(g.Vertices())
+	if len(tour.Vertices) != n+1 {
+		t.Fatalf("tour %v has %d stops, want %d", tour.Vertices, len(tour.Vertices), n+1)
+	}
+	if tour.Vertices[0] != start || tour.Vertices[n] != start {
+		t.Errorf("tour %v should start and end at %v", tour.Vertices, start)
+	}
+	seen := make(map[T]bool)
+	cost := 0.0
+	for i, v := range tour.Vertices[:n] {
+		if seen[v] {
+			t.Errorf("tour %v visits %v twice", tour.Vertices, v)
+		}
+		seen[v] = true
+		cost += g.Neighbors(v)[tour.Vertices[i+1]]
+	}
+	if math.Abs(cost-tour.Cost) > 1e-9 {
+		t.Errorf("tour cost = %v, edges sum to %v", tour.Cost, cost)
+	}
+}
+
+func TestNearestNeighborTour(t *testing.T) {
+	g := New[string](false)
+	g.AddEdge("A", "B", 1)
+	g.AddEdge("B", "C", 1)
+	g.AddEdge("C", "D", 1)
+	g.AddEdge("D", "A", 1)
+	g.AddEdge("A", "C", 5)
+	g.AddEdge("B", "D", 5)
+
+	tour, ok := NearestNeighborTour(g, "A")
+	if !ok {
+		t.Fatal("tour should exist")
+	}
+	checkTour(t, g, tour, "A")
+	if tour.Cost != 4 {
+		t.Errorf("tour cost = %v, want 4", tour.Cost)
+	}
+}
+
+func TestNearestNeighborTourStuck(t *testing.T) {
+	g := New[int](false)
+	g.AddEdge(1, 2, 1)
+	g.AddEdge(2, 3, 1)
+	if _, ok := NearestNeighborTour(g, 1); ok {
+		t.Error("path graph cannot be closed into a tour")
+	}
+
+	star := New[int](false)
+	star.AddEdge(0, 1, 1)
+	star.AddEdge(0, 2, 1)
+	if _, ok := NearestNeighborTour(star, 0); ok {
+		t.Error("walk should get stuck on a star")
+	}
+	if _, ok := NearestNeighborTour(star, 9); ok {
+		t.Error("unknown start should fail")
+	}
+}
+
+func TestTwoOpt(t *testing.T) {
+	// Points on a circle: the optimal tour follows the circle.
+	r := rand.New(rand.NewPCG(5, 5))
+	var points []point
+	for i := 0; i < 30; i++ {
+		a := 2 * math.Pi * float64(i) / 30
+		points = append(points, point{math.Cos(a), math.Sin(a)})
+	}
+	r.Shuffle(len(points), func(i, j int) { points[i], points[j] = points[j], points[i] })
+	g := completeEuclidean(points)
+
+	nn, ok := NearestNeighborTour(g, 0)
+	if !ok {
+		t.Fatal("complete graph should have a tour")
+	}
+	better := TwoOpt(g, nn)
+	checkTour(t, g, better, 0)
+	if better.Cost > nn.Cost+1e-9 {
+		t.Errorf("2-opt made the tour worse: %v > %v", better.Cost, nn.Cost)
+	}
+	optimal := 30 * 2 * math.Sin(math.Pi/30)
+	if math.Abs(better.Cost-optimal) > 1e-9 {
+		t.Errorf("2-opt cost = %v, want the circle %v", better.Cost, optimal)
+	}
+}
+
+func TestTwoOptUncrossesTour(t *testing.T) {
+	g := completeEuclidean([]point{{0, 0}, {1, 0}, {1, 1}, {0, 1}})
+	crossed := Path[int]{Vertices: []int{0, 2, 1, 3, 0}}
+
+	fixed := TwoOpt(g, crossed)
+	checkTour(t, g, fixed, 0)
+	if math.Abs(fixed.Cost-4) > 1e-9 {
+		t.Errorf("cost = %v, want 4", fixed.Cost)
+	}
+	if crossed.Vertices[1] != 2 {
+		t.Error("TwoOpt should not modify its input")
+	}
+}
+
+func TestTwoOptDirected(t *testing.T) {
+	// Only the clockwise direction of the square is cheap.
+	g := New[int](true)
+	for i := 0; i < 4; i++ {
+		for j := 0; j < 4; j++ {
+			if i != j {
+				g.AddEdge(i, j, 10)
+			}
+		}
+	}
+	for i := 0; i < 4; i++ {
+		g.AddEdge(i, (i+1)%4, 1)
+	}
+
+	tour := TwoOpt(g, Path[int]{Vertices: []int{0, 2, 1, 3, 0}})
+	checkTour(t, g, tour, 0)
+	if tour.Cost >= 40 {
+		t.Errorf("2-opt should improve the directed tour, cost %v", tour.Cost)
+	}
+}
@@ -0,0 +1,181 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrClosed is returned when putting into a closed queue, or taking from a
+// closed queue that has no elements left.
+var ErrClosed = errors.New("queue: closed")
+
+// cond is a condition variable whose waiters can give up when a context is
+// done. It must be used with an external mutex held; the zero value is
+// ready to use.
+type cond struct {
+	ch chan struct{}
+}
+
+// wait returns a channel that is closed by the next broadcast.
+func (c *cond) wait() <-chan struct{} {
+	if c.ch == nil {
+		c.ch = make(chan struct{})
+	}
+	return c.ch
+}
+
+// broadcast wakes all current waiters.
+func (c *cond) broadcast() {
+	if c.ch != nil {
+		close(c.ch)
+		c.ch = nil
+	}
+}
+
+// BlockingQueue is a FIFO queue that is safe for concurrent use, for
+// producer/consumer pipelines. Put blocks while the queue is full and Take
+// blocks while it is empty.
+type BlockingQueue[T any] struct {
+	mu       sync.Mutex
+	q        Queue[T]
+	capacity int
+	closed   bool
+	notEmpty cond
+	notFull  cond
+}
+
+// NewBlockingQueue creates a BlockingQueue holding at most capacity
+// elements. A capacity of 0 or less means the queue is unbounded.
+func NewBlockingQueue[T any](capacity int) *BlockingQueue[T] {
+	return &BlockingQueue[T]{capacity: max(capacity, 0)}
+}
+
+// Put adds v to the back of the queue, waiting for space if the queue is
+// full. It returns ErrClosed if the queue is closed, or ctx.Err() if ctx is
+// done before space is available.
+func (b *BlockingQueue[T]) Put(ctx context.Context, v T) error {
+	b.mu.Lock()
+	for {
+		if b.closed {
+			b.mu.Unlock()
+			return ErrClosed
+		}
+		if b.capacity == 0 || b.q.Len() < b.capacity {
+			b.q.Enqueue(v)
+			b.notEmpty.broadcast()
+			b.mu.Unlock()
+			return nil
+		}
+		ready := b.notFull.wait()
+		b.mu.Unlock()
+		select {
+		case <-ready:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		b.mu.Lock()
+	}
+}
+
+// Take removes and returns the front element, waiting for one if the queue
+// is empty. After Close, Take keeps returning the remaining elements and
+// then ErrClosed. It returns ctx.Err() if ctx is done before an element is
+// available.
+func (b *BlockingQueue[T]) Take(ctx context.Context) (T, error) {
+	b.mu.Lock()
+	for {
+		if v, ok := b.q.Dequeue(); ok {
+			b.notFull.broadcast()
+			b.mu.Unlock()
+			return v, nil
+		}
+		if b.closed {
+			b.mu.Unlock()
+			var zero T
+			return zero, ErrClosed
+		}
+		ready := b.notEmpty.wait()
+		b.mu.Unlock()
+		select {
+		case <-ready:
+		case <-ctx.Done():
+			var zero T
+			return zero, ctx.Err()
+		}
+		b.mu.Lock()
+	}
+}
+
+// TryPut adds v without waiting. It reports false if the queue is full or
+// closed.
+func (b *BlockingQueue[T]) TryPut(v T) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed || b.capacity > 0 && b.q.Len() >= b.capacity {
+		return false
+	}
+	b.q.Enqueue(v)
+	b.notEmpty.broadcast()
+	return true
+}
+
+// TryTake removes and returns the front element without waiting.
+// The boolean is false when the queue is empty.
+func (b *BlockingQueue[T]) TryTake() (T, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	v, ok := b.q.Dequeue()
+	if ok {
+		b.notFull.broadcast()
+	}
+	return v, ok
+}
+
+// DrainTo removes up to limit elements without waiting and appends them to
+// dst in FIFO order, returning the extended slice. If limit is 0 or less,
+// all elements are removed.
+func (b *BlockingQueue[T]) DrainTo(dst []T, limit int) []T {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	n := b.q.Len()
+	if limit > 0 && limit < n {
+		n = limit
+	}
+	for i := 0; i < n; i++ {
+		v, _ := b.q.Dequeue()
+		dst = append(dst, v)
+	}
+	if n > 0 {
+		b.notFull.broadcast()
+	}
+	return dst
+}
+
+// Close stops the queue from accepting new elements and wakes all waiting
+// callers. Elements already queued can still be taken. Closing an already
+// closed queue has no effect.
+func (b *BlockingQueue[T]) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.closed = true
+	b.notEmpty.broadcast()
+	b.notFull.broadcast()
+}
+
+// IsClosed reports whether Close has been called.
+func (b *BlockingQueue[T]) IsClosed() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.closed
+}
+
+// Len returns the number of queued elements.
+func (b *BlockingQueue[T]) Len() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.q.Len()
+}
+
+// Cap returns the capacity of the queue, or 0 if it is unbounded.
+func (b *BlockingQueue[T]) Cap() int { return b.capacity }
@@ -0,0 +1,214 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestBlockingQueueFIFO(t *testing.T) {
+	b := NewBlockingQueue[int](0)
+	ctx := context.Background()
+	for i := 0; i < 5; i++ {
+		if err := b.Put(ctx, i); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if b.Len() != 5 || b.Cap() != 0 {
+		t.Errorf("Len() = %d, Cap() = %d", b.Len(), b.Cap())
+	}
+	for i := 0; i < 5; i++ {
+		if v, err := b.Take(ctx); err != nil || v != i {
+			t.Errorf("Take() = %d, %v, want %d", v, err, i)
+		}
+	}
+}
+
+func TestBlockingQueueTry(t *testing.T) {
+	b := NewBlockingQueue[string](2)
+	if !b.TryPut("a") || !b.TryPut("b") {
+		t.Fatal("TryPut should succeed below capacity")
+	}
+	if b.TryPut("c") {
+		t.Error("TryPut should fail when full")
+	}
+	if v, ok := b.TryTake(); !ok || v != "a" {
+		t.Errorf("TryTake() = %q, %v", v, ok)
+	}
+	if !b.TryPut("c") {
+		t.Error("TryPut should succeed after a take")
+	}
+	b.TryTake()
+	b.TryTake()
+	if _, ok := b.TryTake(); ok {
+		t.Error("TryTake on empty queue should fail")
+	}
+}
+
+func TestBlockingQueuePutBlocksWhenFull(t *testing.T) {
+	b := NewBlockingQueue[int](1)
+	ctx := context.Background()
+	b.Put(ctx, 1)
+
+	done := make(chan error)
+	go func() { done <- b.Put(ctx, 2) }()
+
+	select {
+	case <-done:
+		t.Fatal("Put should block while the queue is full")
+	case <-time.After(20 * time.Millisecond):
+	}
+	if v, _ := b.Take(ctx); v != 1 {
+		t.Errorf("Take() = %d, want 1", v)
+	}
+	if err := <-done; err != nil {
+		t.Fatalf("blocked Put failed: %v", err)
+	}
+	if v, _ := b.Take(ctx); v != 2 {
+		t.Errorf("Take() = %d, want 2", v)
+	}
+}
+
+func TestBlockingQueueContext(t *testing.T) {
+	b := NewBlockingQueue[int](1)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := b.Take(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Take on empty queue: err = %v, want DeadlineExceeded", err)
+	}
+
+	b.TryPut(1)
+	ctx2, cancel2 := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancel2()
+	}()
+	if err := b.Put(ctx2, 2); !errors.Is(err, context.Canceled) {
+		t.Errorf("Put on full queue: err = %v, want Canceled", err)
+	}
+	if b.Len() != 1 {
+		t.Errorf("cancelled Put should not add, Len() = %d", b.Len())
+	}
+}
+
+func TestBlockingQueueClose(t *testing.T) {
+	b := NewBlockingQueue[int](0)
+	ctx := context.Background()
+	b.Put(ctx, 1)
+	b.Put(ctx, 2)
+
+	waiter := NewBlockingQueue[int](0)
+	woke := make(chan error)
+	go func() {
+		_, err := waiter.Take(ctx)
+		woke <- err
+	}()
+	time.Sleep(10 * time.Millisecond)
+	waiter.Close()
+	if err := <-woke; !errors.Is(err, ErrClosed) {
+		t.Errorf("waiting Take after Close: err = %v, want ErrClosed", err)
+	}
+
+	b.Close()
+	b.Close()
+	if !b.IsClosed() {
+		t.Error("IsClosed() should be true")
+	}
+	if err := b.Put(ctx, 3); !errors.Is(err, ErrClosed) {
+		t.Errorf("Put after Close: err = %v, want ErrClosed", err)
+	}
+	if b.TryPut(3) {
+		t.Error("TryPut after Close should fail")
+	}
+	// Remaining elements drain before ErrClosed.
+	for _, want := range []int{1, 2} {
+		if v, err := b.Take(ctx); err != nil || v != want {
+			t.Errorf("Take() = %d, %v, want %d", v, err, want)
+		}
+	}
+	if _, err := b.Take(ctx); !errors.Is(err, ErrClosed) {
+		t.Errorf("Take on drained closed queue: err = %v, want ErrClosed", err)
+	}
+}
+
+func TestBlockingQueueDrainTo(t *testing.T) {
+	b := NewBlockingQueue[int](0)
+	for i := 0; i < 10; i++ {
+		b.TryPut(i)
+	}
+	batch := b.DrainTo(nil, 4)
+	if want := []int{0, 1, 2, 3}; !slices.Equal(batch, want) {
+		t.Errorf("DrainTo(nil, 4) = %v, want %v", batch, want)
+	}
+	batch = b.DrainTo(batch[:0], 0)
+	if want := []int{4, 5, 6, 7, 8, 9}; !slices.Equal(batch, want) {
+		t.Errorf("DrainTo(_, 0) = %v, want %v", batch, want)
+	}
+	if got := b.DrainTo(nil, 5); len(got) != 0 {
+		t.Errorf("DrainTo on empty queue = %v", got)
+	}
+}
+
+func TestBlockingQueueProducersConsumers(t *testing.T) {
+	b := NewBlockingQueue[int](8)
+	ctx := context.Background()
+	const producers, perProducer = 4, 500
+
+	var wg sync.WaitGroup
+	for p := 0; p < producers; p++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < perProducer; i++ {
+				if err := b.Put(ctx, p*perProducer+i); err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		}()
+	}
+
+	results := make(chan []int)
+	for c := 0; c < 3; c++ {
+		go func() {
+			var got []int
+			for {
+				v, err := b.Take(ctx)
+				if errors.Is(err, ErrClosed) {
+					results <- got
+					return
+				}
+				got = append(got, v)
+			}
+		}()
+	}
+
+	wg.Wait()
+	b.Close()
+	seen := make(map[int]bool)
+	for c := 0; c < 3; c++ {
+		for _, v := range <-results {
+			if seen[v] {
+				t.Fatalf("value %d taken twice", v)
+			}
+			seen[v] = true
+		}
+	}
+	if len(seen) != producers*perProducer {
+		t.Errorf("took %d values, want %d", len(seen), producers*perProducer)
+	}
+}
+
+func BenchmarkBlockingQueue(b *testing.B) {
+	q := NewBlockingQueue[int](1024)
+	ctx := context.Background()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			q.Put(ctx, 1)
+			q.Take(ctx)
+		}
+	})
+}
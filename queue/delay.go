@@ -0,0 +1,122 @@
+package queue
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Clock tells the time. DelayQueue uses it so tests can control time
+// instead of sleeping.
+type Clock interface {
+	Now() time.Time
+	// After returns a channel that receives the time once d has elapsed.
+	After(d time.Duration) <-chan time.Time
+}
+
+// systemClock is the Clock backed by package time.
+type systemClock struct{}
+
+func (systemClock) Now() time.Time                         { return time.Now() }
+func (systemClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
+// SystemClock is the Clock that reads the system time.
+var SystemClock Clock = systemClock{}
+
+type delayed[T any] struct {
+	value   T
+	readyAt time.Time
+}
+
+// DelayQueue holds elements until a scheduled time, for retries and
+// timeouts. Elements become available once their ready time has passed and
+// are taken in order of ready time; elements with the same ready time come
+// out in the order they were pushed. It is safe for concurrent use.
+type DelayQueue[T any] struct {
+	mu      sync.Mutex
+	clock   Clock
+	pq      *PriorityQueue[delayed[T]]
+	changed cond
+}
+
+// NewDelayQueue creates an empty DelayQueue that reads the time from
+// clock, or from SystemClock if clock is nil.
+func NewDelayQueue[T any](clock Clock) *DelayQueue[T] {
+	if clock == nil {
+		clock = SystemClock
+	}
+	return &DelayQueue[T]{
+		clock: clock,
+		pq:    NewStablePriorityQueue(func(a, b delayed[T]) bool { return a.readyAt.Before(b.readyAt) }),
+	}
+}
+
+// Push adds v, to become available at readyAt.
+func (q *DelayQueue[T]) Push(v T, readyAt time.Time) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.pq.Push(delayed[T]{v, readyAt})
+	// The new element may be due before the one Take is waiting for.
+	q.changed.broadcast()
+}
+
+// Take removes and returns the element with the earliest ready time,
+// waiting until that time has passed. It returns ctx.Err() if ctx is done
+// first.
+func (q *DelayQueue[T]) Take(ctx context.Context) (T, error) {
+	q.mu.Lock()
+	for {
+		var timer <-chan time.Time
+		if next, ok := q.pq.Peek(); ok {
+			wait := next.readyAt.Sub(q.clock.Now())
+			if wait <= 0 {
+				q.pq.Pop()
+				q.mu.Unlock()
+				return next.value, nil
+			}
+			timer = q.clock.After(wait)
+		}
+		changed := q.changed.wait()
+		q.mu.Unlock()
+		select {
+		case <-changed:
+		case <-timer:
+		case <-ctx.Done():
+			var zero T
+			return zero, ctx.Err()
+		}
+		q.mu.Lock()
+	}
+}
+
+// Poll removes and returns the element with the earliest ready time if
+// that time has passed, without waiting.
+// The boolean is false when no element is ready.
+func (q *DelayQueue[T]) Poll() (T, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	next, ok := q.pq.Peek()
+	if !ok || next.readyAt.After(q.clock.Now()) {
+		var zero T
+		return zero, false
+	}
+	q.pq.Pop()
+	return next.value, true
+}
+
+// Peek returns the element with the earliest ready time and that time,
+// whether or not it has passed, without removing it.
+// The boolean is false when the queue is empty.
+func (q *DelayQueue[T]) Peek() (T, time.Time, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	next, ok := q.pq.Peek()
+	return next.value, next.readyAt, ok
+}
+
+// Len returns the number of elements, ready or not.
+func (q *DelayQueue[T]) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.pq.Len()
+}
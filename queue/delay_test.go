@@ -0,0 +1,206 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeClock is a Clock whose time only moves when Advance is called.
+type fakeClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	timers  []fakeTimer
+	waiting chan struct{} // receives a value whenever After is called
+}
+
+type fakeTimer struct {
+	at time.Time
+	ch chan time.Time
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), waiting: make(chan struct{}, 100)}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ch := make(chan time.Time, 1)
+	c.timers = append(c.timers, fakeTimer{c.now.Add(d), ch})
+	c.waiting <- struct{}{}
+	return ch
+}
+
+// Advance moves the clock forward and fires the timers that are due.
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+	pending := c.timers[:0]
+	for _, t := range c.timers {
+		if t.at.After(c.now) {
+			pending = append(pending, t)
+		} else {
+			t.ch <- c.now
+		}
+	}
+	c.timers = pending
+}
+
+func TestDelayQueuePoll(t *testing.T) {
+	clock := newFakeClock()
+	q := NewDelayQueue[string](clock)
+	start := clock.Now()
+	q.Push("late", start.Add(3*time.Second))
+	q.Push("early", start.Add(time.Second))
+	q.Push("now", start)
+
+	if q.Len() != 3 {
+		t.Errorf("Len() = %d, want 3", q.Len())
+	}
+	if v, ok := q.Poll(); !ok || v != "now" {
+		t.Errorf("Poll() = %q, %v, want now", v, ok)
+	}
+	if _, ok := q.Poll(); ok {
+		t.Error("Poll should fail before the next deadline")
+	}
+	if v, at, ok := q.Peek(); !ok || v != "early" || !at.Equal(start.Add(time.Second)) {
+		t.Errorf("Peek() = %q, %v, %v", v, at, ok)
+	}
+
+	clock.Advance(5 * time.Second)
+	for _, want := range []string{"early", "late"} {
+		if v, ok := q.Poll(); !ok || v != want {
+			t.Errorf("Poll() = %q, %v, want %q", v, ok, want)
+		}
+	}
+	if _, _, ok := q.Peek(); ok {
+		t.Error("Peek on empty queue should fail")
+	}
+}
+
+func TestDelayQueueTakeWaits(t *testing.T) {
+	clock := newFakeClock()
+	q := NewDelayQueue[int](clock)
+	q.Push(1, clock.Now().Add(10*time.Second))
+
+	got := make(chan int)
+	go func() {
+		v, err := q.Take(context.Background())
+		if err != nil {
+			t.Error(err)
+		}
+		got <- v
+	}()
+
+	<-clock.waiting // Take is waiting for the 10s deadline
+	clock.Advance(9 * time.Second)
+	select {
+	case v := <-got:
+		t.Fatalf("Take returned %d before the deadline", v)
+	default:
+	}
+	clock.Advance(time.Second)
+	if v := <-got; v != 1 {
+		t.Errorf("Take() = %d, want 1", v)
+	}
+}
+
+func TestDelayQueueEarlierPushWakesTake(t *testing.T) {
+	clock := newFakeClock()
+	q := NewDelayQueue[string](clock)
+	q.Push("hour", clock.Now().Add(time.Hour))
+
+	got := make(chan string)
+	go func() {
+		v, _ := q.Take(context.Background())
+		got <- v
+	}()
+	<-clock.waiting
+
+	// A sooner element must not wait behind the hour-long timer.
+	q.Push("minute", clock.Now().Add(time.Minute))
+	<-clock.waiting
+	clock.Advance(time.Minute)
+	if v := <-got; v != "minute" {
+		t.Errorf("Take() = %q, want minute", v)
+	}
+	if q.Len() != 1 {
+		t.Errorf("Len() = %d, want 1", q.Len())
+	}
+}
+
+func TestDelayQueueTakeEmpty(t *testing.T) {
+	clock := newFakeClock()
+	q := NewDelayQueue[int](clock)
+
+	got := make(chan int)
+	go func() {
+		v, _ := q.Take(context.Background())
+		got <- v
+	}()
+	// With nothing queued Take waits for a push rather than a timer.
+	time.Sleep(10 * time.Millisecond)
+	q.Push(7, clock.Now())
+	if v := <-got; v != 7 {
+		t.Errorf("Take() = %d, want 7", v)
+	}
+}
+
+func TestDelayQueueTakeContext(t *testing.T) {
+	clock := newFakeClock()
+	q := NewDelayQueue[int](clock)
+	q.Push(1, clock.Now().Add(time.Hour))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errs := make(chan error)
+	go func() {
+		_, err := q.Take(ctx)
+		errs <- err
+	}()
+	<-clock.waiting
+	cancel()
+	if err := <-errs; !errors.Is(err, context.Canceled) {
+		t.Errorf("err = %v, want context.Canceled", err)
+	}
+	if q.Len() != 1 {
+		t.Error("cancelled Take should not remove the element")
+	}
+}
+
+func TestDelayQueueSameDeadline(t *testing.T) {
+	clock := newFakeClock()
+	q := NewDelayQueue[int](clock)
+	at := clock.Now().Add(time.Second)
+	for i := 0; i < 1000; i++ {
+		q.Push(i, at)
+	}
+	clock.Advance(time.Second)
+	for i := 0; i < 1000; i++ {
+		if v, ok := q.Poll(); !ok || v != i {
+			t.Fatalf("Poll() = %d, %v, want %d", v, ok, i)
+		}
+	}
+}
+
+func TestDelayQueueSystemClock(t *testing.T) {
+	q := NewDelayQueue[string](nil)
+	q.Push("soon", time.Now().Add(5*time.Millisecond))
+	start := time.Now()
+	v, err := q.Take(context.Background())
+	if err != nil || v != "soon" {
+		t.Fatalf("Take() = %q, %v", v, err)
+	}
+	if elapsed := time.Since(start); elapsed < 4*time.Millisecond {
+		t.Errorf("Take returned after %v, before the deadline", elapsed)
+	}
+}
@@ -1,5 +1,7 @@
 package queue
 
+import "iter"
+
 // Deque is a double-ended queue implemented as a ring buffer.
 // Zero value is ready to use.
 type Deque[T any] struct {
@@ -28,6 +30,9 @@ func (d *Deque[T]) growIfNeeded() {
 	d.tail = d.size
 }
 
+// index returns the position in buf of the element at index i.
+func (d *Deque[T]) index(i int) int { return (d.head + i) % len(d.buf) }
+
 // Len returns the number of elements in the deque.
 func (d *Deque[T]) Len() int { return d.size }
 
@@ -103,6 +108,152 @@ func (d *Deque[T]) PeekBack() (T, bool) {
 	return d.buf[idx], true
 }
 
+// At returns the element at index i, counting from the front, in O(1).
+// The boolean is false if i is out of range.
+func (d *Deque[T]) At(i int) (T, bool) {
+	if i < 0 || i >= d.size {
+		var zero T
+		return zero, false
+	}
+	return d.buf[d.index(i)], true
+}
+
+// Set replaces the element at index i, counting from the front, in O(1).
+// It reports false, and does nothing, if i is out of range.
+func (d *Deque[T]) Set(i int, v T) bool {
+	if i < 0 || i >= d.size {
+		return false
+	}
+	d.buf[d.index(i)] = v
+	return true
+}
+
+// Insert adds v at index i, counting from the front, so that it is then at
+// index i. i may equal Len to add at the back. Only the elements between i
+// and the nearer end are moved, so it takes O(min(i, Len-i)) time.
+// It reports false, and does nothing, if i is out of range.
+func (d *Deque[T]) Insert(i int, v T) bool {
+	if i < 0 || i > d.size {
+		return false
+	}
+	d.growIfNeeded()
+	if i < d.size/2 {
+		d.head = (d.head - 1 + len(d.buf)) % len(d.buf)
+		d.size++
+		for j := 0; j < i; j++ {
+			d.buf[d.index(j)] = d.buf[d.index(j+1)]
+		}
+	} else {
+		d.tail = (d.tail + 1) % len(d.buf)
+		d.size++
+		for j := d.size - 1; j > i; j-- {
+			d.buf[d.index(j)] = d.buf[d.index(j-1)]
+		}
+	}
+	d.buf[d.index(i)] = v
+	return true
+}
+
+// Remove removes and returns the element at index i, counting from the
+// front. Only the elements between i and the nearer end are moved, so it
+// takes O(min(i, Len-i)) time.
+// The boolean is false if i is out of range.
+func (d *Deque[T]) Remove(i int) (T, bool) {
+	if i < 0 || i >= d.size {
+		var zero T
+		return zero, false
+	}
+	v := d.buf[d.index(i)]
+	if i < d.size/2 {
+		for j := i; j > 0; j-- {
+			d.buf[d.index(j)] = d.buf[d.index(j-1)]
+		}
+		d.PopFront()
+	} else {
+		for j := i; j < d.size-1; j++ {
+			d.buf[d.index(j)] = d.buf[d.index(j+1)]
+		}
+		d.PopBack()
+	}
+	return v, true
+}
+
+// Swap exchanges the elements at indexes i and j.
+// It reports false, and does nothing, if either is out of range.
+func (d *Deque[T]) Swap(i, j int) bool {
+	if i < 0 || i >= d.size || j < 0 || j >= d.size {
+		return false
+	}
+	a, b := d.index(i), d.index(j)
+	d.buf[a], d.buf[b] = d.buf[b], d.buf[a]
+	return true
+}
+
+// Reverse reverses the order of the elements in place.
+func (d *Deque[T]) Reverse() {
+	for i, j := 0, d.size-1; i < j; i, j = i+1, j-1 {
+		d.Swap(i, j)
+	}
+}
+
+// Rotate moves every element n steps towards the back, wrapping elements
+// off the back around to the front; a negative n rotates towards the
+// front. Rotate(1) is equivalent to PopBack followed by PushFront. It takes
+// O(1) time when the buffer is full and O(min(n, Len-n)) otherwise.
+func (d *Deque[T]) Rotate(n int) {
+	if d.size < 2 {
+		return
+	}
+	n %= d.size
+	if n < 0 {
+		n += d.size
+	}
+	if n == 0 {
+		return
+	}
+	if d.size == len(d.buf) {
+		// Every slot is in use, so moving head and tail together rotates.
+		d.head = d.index(d.size - n)
+		d.tail = d.head
+		return
+	}
+	if n <= d.size/2 {
+		for range n {
+			v, _ := d.PopBack()
+			d.PushFront(v)
+		}
+	} else {
+		for range d.size - n {
+			v, _ := d.PopFront()
+			d.PushBack(v)
+		}
+	}
+}
+
+// All returns an iterator over index-element pairs from front to back.
+// The deque must not be modified during iteration.
+func (d *Deque[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i := 0; i < d.size; i++ {
+			if !yield(i, d.buf[d.index(i)]) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns an iterator over index-element pairs from back to front.
+// The deque must not be modified during iteration.
+func (d *Deque[T]) Backward() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i := d.size - 1; i >= 0; i-- {
+			if !yield(i, d.buf[d.index(i)]) {
+				return
+			}
+		}
+	}
+}
+
 // ToSlice returns elements from front to back.
 func (d *Deque[T]) ToSlice() []T {
 	out := make([]T, d.size)
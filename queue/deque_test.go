@@ -1,6 +1,8 @@
 package queue
 
 import (
+	"math/rand/v2"
+	"slices"
 	"testing"
 )
 
@@ -429,6 +431,185 @@ func TestDequeLargeDeque(t *testing.T) {
 	}
 }
 
+func TestDequeAtSet(t *testing.T) {
+	d := NewDeque[int]()
+	for i := 0; i < 5; i++ {
+		d.PushBack(i)
+	}
+	d.PopFront()
+	d.PushBack(5) // wraps around the buffer
+	for i := 0; i < d.Len(); i++ {
+		if v, ok := d.At(i); !ok || v != i+1 {
+			t.Errorf("At(%d) = %d, %v; want %d, true", i, v, ok, i+1)
+		}
+	}
+	if !d.Set(4, 50) {
+		t.Error("Set(4) should succeed")
+	}
+	if v, _ := d.PeekBack(); v != 50 {
+		t.Errorf("PeekBack() after Set = %d, want 50", v)
+	}
+	for _, i := range []int{-1, 5} {
+		if _, ok := d.At(i); ok {
+			t.Errorf("At(%d) should fail", i)
+		}
+		if d.Set(i, 0) {
+			t.Errorf("Set(%d) should fail", i)
+		}
+	}
+	if _, ok := NewDeque[int]().At(0); ok {
+		t.Error("At on an empty deque should fail")
+	}
+}
+
+func TestDequeInsertRemove(t *testing.T) {
+	d := DequeFromSlice([]int{1, 2, 4, 5})
+	if !d.Insert(2, 3) || !d.Insert(0, 0) || !d.Insert(d.Len(), 6) {
+		t.Fatal("Insert within range should succeed")
+	}
+	if got, want := d.ToSlice(), []int{0, 1, 2, 3, 4, 5, 6}; !slices.Equal(got, want) {
+		t.Errorf("after Insert = %v, want %v", got, want)
+	}
+	if d.Insert(-1, 0) || d.Insert(d.Len()+1, 0) {
+		t.Error("Insert out of range should fail")
+	}
+	if v, ok := d.Remove(3); !ok || v != 3 {
+		t.Errorf("Remove(3) = %d, %v; want 3, true", v, ok)
+	}
+	if v, ok := d.Remove(0); !ok || v != 0 {
+		t.Errorf("Remove(0) = %d, %v; want 0, true", v, ok)
+	}
+	if got, want := d.ToSlice(), []int{1, 2, 4, 5, 6}; !slices.Equal(got, want) {
+		t.Errorf("after Remove = %v, want %v", got, want)
+	}
+	if _, ok := d.Remove(d.Len()); ok {
+		t.Error("Remove out of range should fail")
+	}
+
+	empty := NewDeque[string]()
+	if !empty.Insert(0, "a") {
+		t.Fatal("Insert(0) on an empty deque should succeed")
+	}
+	if v, _ := empty.PeekFront(); v != "a" {
+		t.Errorf("PeekFront() = %q, want %q", v, "a")
+	}
+}
+
+func TestDequeRandomAccessMatchesSlice(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	d := NewDeque[int]()
+	var model []int
+	for step := 0; step < 5000; step++ {
+		switch op := r.IntN(6); {
+		case op == 0 || len(model) == 0:
+			i := r.IntN(len(model) + 1)
+			d.Insert(i, step)
+			model = slices.Insert(model, i, step)
+		case op == 1:
+			i := r.IntN(len(model))
+			v, _ := d.Remove(i)
+			if v != model[i] {
+				t.Fatalf("step %d: Remove(%d) = %d, want %d", step, i, v, model[i])
+			}
+			model = slices.Delete(model, i, i+1)
+		case op == 2:
+			n := r.IntN(2*len(model)+1) - len(model)
+			d.Rotate(n)
+			model = rotate(model, n)
+		case op == 3:
+			i, j := r.IntN(len(model)), r.IntN(len(model))
+			d.Swap(i, j)
+			model[i], model[j] = model[j], model[i]
+		case op == 4:
+			d.PushFront(step)
+			model = slices.Insert(model, 0, step)
+		default:
+			d.PopBack()
+			model = model[:len(model)-1]
+		}
+		if got := d.ToSlice(); !slices.Equal(got, model) {
+			t.Fatalf("step %d: deque = %v, want %v", step, got, model)
+		}
+	}
+}
+
+// rotate is the reference implementation of Deque.Rotate on a slice.
+func rotate(s []int, n int) []int {
+	if len(s) == 0 {
+		return s
+	}
+	n = ((n % len(s)) + len(s)) % len(s)
+	return append(slices.Clone(s[len(s)-n:]), s[:len(s)-n]...)
+}
+
+func TestDequeRotate(t *testing.T) {
+	d := DequeFromSlice([]int{1, 2, 3, 4, 5})
+	d.Rotate(2)
+	if got, want := d.ToSlice(), []int{4, 5, 1, 2, 3}; !slices.Equal(got, want) {
+		t.Errorf("Rotate(2) = %v, want %v", got, want)
+	}
+	d.Rotate(-3)
+	if got, want := d.ToSlice(), []int{2, 3, 4, 5, 1}; !slices.Equal(got, want) {
+		t.Errorf("Rotate(-3) = %v, want %v", got, want)
+	}
+	d.Rotate(11)
+	if got, want := d.ToSlice(), []int{1, 2, 3, 4, 5}; !slices.Equal(got, want) {
+		t.Errorf("Rotate(11) = %v, want %v", got, want)
+	}
+
+	// A full buffer rotates by moving head and tail.
+	full := DequeFromSlice([]int{1, 2, 3, 4})
+	full.Rotate(1)
+	full.PushBack(5)
+	if got, want := full.ToSlice(), []int{4, 1, 2, 3, 5}; !slices.Equal(got, want) {
+		t.Errorf("full Rotate(1) then PushBack = %v, want %v", got, want)
+	}
+
+	NewDeque[int]().Rotate(3) // must not panic
+}
+
+func TestDequeReverse(t *testing.T) {
+	for n := 0; n < 6; n++ {
+		d := NewDeque[int]()
+		var want []int
+		for i := 0; i < n; i++ {
+			d.PushFront(i)
+			want = append(want, i)
+		}
+		d.Reverse()
+		if got := d.ToSlice(); !slices.Equal(got, want) {
+			t.Errorf("Reverse of %d elements = %v, want %v", n, got, want)
+		}
+	}
+}
+
+func TestDequeIterators(t *testing.T) {
+	d := DequeFromSlice([]int{10, 20, 30})
+	d.PopFront()
+	d.PushBack(40)
+
+	var idx, vals []int
+	for i, v := range d.All() {
+		idx = append(idx, i)
+		vals = append(vals, v)
+	}
+	if !slices.Equal(idx, []int{0, 1, 2}) || !slices.Equal(vals, []int{20, 30, 40}) {
+		t.Errorf("All() = %v %v", idx, vals)
+	}
+
+	idx, vals = nil, nil
+	for i, v := range d.Backward() {
+		idx = append(idx, i)
+		vals = append(vals, v)
+		if len(vals) == 2 {
+			break
+		}
+	}
+	if !slices.Equal(idx, []int{2, 1}) || !slices.Equal(vals, []int{40, 30}) {
+		t.Errorf("Backward() with break = %v %v", idx, vals)
+	}
+}
+
 // Benchmarks
 func BenchmarkPushFront(b *testing.B) {
 	d := NewDeque[int]()
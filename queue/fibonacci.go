@@ -0,0 +1,247 @@
+package queue
+
+// FibNode is an element of a FibonacciHeap, returned by Push for use with
+// DecreaseKey.
+type FibNode[T any] struct {
+	value       T
+	parent      *FibNode[T]
+	child       *FibNode[T] // any one child; children form a circular list
+	left, right *FibNode[T] // siblings in a circular doubly linked list
+	degree      int         // number of children
+	mark        bool        // lost a child since becoming a child itself
+	popped      bool
+}
+
+// Value returns the element stored in the node.
+func (n *FibNode[T]) Value() T { return n.value }
+
+// FibonacciHeap is a meldable priority queue. Push, Meld and DecreaseKey
+// take O(1) amortised time and Pop takes O(log n) amortised time.
+type FibonacciHeap[T any] struct {
+	min  *FibNode[T] // top-priority root; roots form a circular list
+	size int
+	less func(a, b T) bool
+}
+
+// NewFibonacciHeap creates a Fibonacci heap using the provided ordering.
+// The function less must return true when a should come before b.
+func NewFibonacciHeap[T any](less func(a, b T) bool) *FibonacciHeap[T] {
+	return &FibonacciHeap[T]{less: less}
+}
+
+// Push inserts an element and returns its node.
+func (h *FibonacciHeap[T]) Push(v T) *FibNode[T] {
+	n := &FibNode[T]{value: v}
+	n.left, n.right = n, n
+	h.addRoot(n)
+	h.size++
+	return n
+}
+
+// Pop removes and returns the top-priority element.
+// The boolean is false when the heap is empty.
+func (h *FibonacciHeap[T]) Pop() (T, bool) {
+	z := h.min
+	if z == nil {
+		var zero T
+		return zero, false
+	}
+	// Promote the children of z to roots.
+	for z.child != nil {
+		c := z.child
+		if c.right == c {
+			z.child = nil
+		} else {
+			z.child = c.right
+			c.unlink()
+		}
+		c.parent = nil
+		c.mark = false
+		z.splice(c)
+	}
+	if z.right == z {
+		h.min = nil
+	} else {
+		h.min = z.right
+		z.unlink()
+		h.consolidate()
+	}
+	z.left, z.right = nil, nil
+	z.popped = true
+	h.size--
+	return z.value, true
+}
+
+// Peek returns the top-priority element without removing it.
+func (h *FibonacciHeap[T]) Peek() (T, bool) {
+	if h.min == nil {
+		var zero T
+		return zero, false
+	}
+	return h.min.value, true
+}
+
+// Len returns the number of elements.
+func (h *FibonacciHeap[T]) Len() int { return h.size }
+
+// IsEmpty reports whether the heap is empty.
+func (h *FibonacciHeap[T]) IsEmpty() bool { return h.size == 0 }
+
+// Clear removes all elements in O(n). Nodes returned by earlier calls to
+// Push are invalidated, so DecreaseKey reports false for them.
+func (h *FibonacciHeap[T]) Clear() {
+	var lists []*FibNode[T] // one node of each circular list still to visit
+	if h.min != nil {
+		lists = append(lists, h.min)
+	}
+	for len(lists) > 0 {
+		first := lists[len(lists)-1]
+		lists = lists[:len(lists)-1]
+		for n := first; ; {
+			next := n.right
+			if n.child != nil {
+				lists = append(lists, n.child)
+			}
+			n.parent, n.child, n.left, n.right = nil, nil, nil, nil
+			n.popped = true
+			if next == first {
+				break
+			}
+			n = next
+		}
+	}
+	h.min = nil
+	h.size = 0
+}
+
+// Meld moves every element of other into h in O(1), leaving other empty.
+// Nodes of other remain valid and now belong to h. Both heaps must use the
+// same ordering.
+func (h *FibonacciHeap[T]) Meld(other *FibonacciHeap[T]) {
+	if other == h || other.min == nil {
+		return
+	}
+	h.addRoot(other.min)
+	h.size += other.size
+	other.min, other.size = nil, 0
+}
+
+// DecreaseKey replaces the element of n, which must belong to h, with v,
+// which must not come after the current element. It reports false, and
+// does nothing, if n has been popped or v would come after the current
+// element.
+func (h *FibonacciHeap[T]) DecreaseKey(n *FibNode[T], v T) bool {
+	if n.popped || h.less(n.value, v) {
+		return false
+	}
+	n.value = v
+	if p := n.parent; p != nil && h.less(n.value, p.value) {
+		h.cut(n)
+		// Cascade up while parents have already lost a child.
+		for p.parent != nil {
+			if !p.mark {
+				p.mark = true
+				break
+			}
+			next := p.parent
+			h.cut(p)
+			p = next
+		}
+	}
+	if h.less(n.value, h.min.value) {
+		h.min = n
+	}
+	return true
+}
+
+// addRoot splices the circular list containing n into the root list and
+// updates min.
+func (h *FibonacciHeap[T]) addRoot(n *FibNode[T]) {
+	if h.min == nil {
+		h.min = n
+		return
+	}
+	h.min.splice(n)
+	if h.less(n.value, h.min.value) {
+		h.min = n
+	}
+}
+
+// cut moves n from its parent's children to the root list.
+func (h *FibonacciHeap[T]) cut(n *FibNode[T]) {
+	p := n.parent
+	if n.right == n {
+		p.child = nil
+	} else {
+		if p.child == n {
+			p.child = n.right
+		}
+		n.unlink()
+	}
+	p.degree--
+	n.parent = nil
+	n.mark = false
+	h.min.splice(n)
+}
+
+// consolidate links roots of equal degree until all degrees are distinct,
+// then finds the new min.
+func (h *FibonacciHeap[T]) consolidate() {
+	var roots []*FibNode[T]
+	for r := h.min; ; {
+		roots = append(roots, r)
+		if r = r.right; r == h.min {
+			break
+		}
+	}
+	var byDegree []*FibNode[T]
+	for _, x := range roots {
+		x.unlink()
+		for {
+			for len(byDegree) <= x.degree {
+				byDegree = append(byDegree, nil)
+			}
+			y := byDegree[x.degree]
+			if y == nil {
+				break
+			}
+			byDegree[x.degree] = nil
+			if h.less(y.value, x.value) {
+				x, y = y, x
+			}
+			// Make y a child of x.
+			y.parent = x
+			y.mark = false
+			if x.child == nil {
+				x.child = y
+			} else {
+				x.child.splice(y)
+			}
+			x.degree++
+		}
+		byDegree[x.degree] = x
+	}
+	h.min = nil
+	for _, r := range byDegree {
+		if r != nil {
+			h.addRoot(r)
+		}
+	}
+}
+
+// unlink removes n from its circular list, leaving it as a list of one.
+func (n *FibNode[T]) unlink() {
+	n.left.right = n.right
+	n.right.left = n.left
+	n.left, n.right = n, n
+}
+
+// splice joins the circular list containing m into the one containing n,
+// just after n.
+func (n *FibNode[T]) splice(m *FibNode[T]) {
+	nRight, mLeft := n.right, m.left
+	n.right = m
+	m.left = n
+	mLeft.right = nRight
+	nRight.left = mLeft
+}
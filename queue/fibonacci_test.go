@@ -0,0 +1,111 @@
+package queue
+
+import "testing"
+
+func TestFibonacciHeap(t *testing.T) {
+	checkHeapSorts[*FibNode[int]](t, NewFibonacciHeap(func(a, b int) bool { return a < b }))
+}
+
+func TestFibonacciHeapDecreaseKey(t *testing.T) {
+	h := NewFibonacciHeap(func(a, b int) bool { return a < b })
+	nodes := make([]*FibNode[int], 20)
+	for i := range nodes {
+		nodes[i] = h.Push(100 + i)
+	}
+	// Popping consolidates the roots into trees, so later decreases cut
+	// nodes out of their parents and cascade.
+	h.Pop()
+	for i := 19; i >= 10; i-- {
+		if !h.DecreaseKey(nodes[i], i-20) {
+			t.Fatalf("DecreaseKey(node %d) failed", i)
+		}
+		if v, _ := h.Peek(); v != i-20 {
+			t.Fatalf("Peek() = %d, want %d", v, i-20)
+		}
+	}
+	if h.DecreaseKey(nodes[5], 200) {
+		t.Error("DecreaseKey to a later value should fail")
+	}
+	if h.DecreaseKey(nodes[0], -100) {
+		t.Error("DecreaseKey on a popped node should fail")
+	}
+	for want := -10; want < 0; want++ {
+		if v, _ := h.Pop(); v != want {
+			t.Fatalf("Pop() = %d, want %d", v, want)
+		}
+	}
+	for want := 101; want < 110; want++ {
+		if v, _ := h.Pop(); v != want {
+			t.Fatalf("Pop() = %d, want %d", v, want)
+		}
+	}
+
+	checkDecreaseKey[*FibNode[int]](t, NewFibonacciHeap(func(a, b int) bool { return a < b }))
+}
+
+func TestFibonacciHeapClear(t *testing.T) {
+	checkClearInvalidates[*FibNode[int]](t, NewFibonacciHeap(func(a, b int) bool { return a < b }))
+}
+
+func TestFibonacciHeapMeld(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+	h1, h2 := NewFibonacciHeap(less), NewFibonacciHeap(less)
+	for i := 0; i < 10; i += 2 {
+		h1.Push(i)
+	}
+	var odd []*FibNode[int]
+	for i := 1; i < 10; i += 2 {
+		odd = append(odd, h2.Push(i))
+	}
+	h1.Pop() // give h1 some tree structure before melding
+	h1.Push(0)
+
+	h1.Meld(h2)
+	h1.Meld(h1)
+	h1.Meld(NewFibonacciHeap(less))
+	if h1.Len() != 10 || !h2.IsEmpty() {
+		t.Fatalf("after Meld: Len() = %d and %d", h1.Len(), h2.Len())
+	}
+	h1.DecreaseKey(odd[4], -1)
+	for _, w := range []int{-1, 0, 1, 2, 3, 4, 5, 6, 7, 8} {
+		if v, _ := h1.Pop(); v != w {
+			t.Fatalf("Pop() = %d, want %d", v, w)
+		}
+	}
+}
+
+func BenchmarkFibonacciHeapPushPop(b *testing.B) {
+	h := NewFibonacciHeap(func(a, b int) bool { return a < b })
+	for i := 0; i < 1000; i++ {
+		h.Push(i)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		h.Push(i % 2000)
+		h.Pop()
+	}
+}
+
+func BenchmarkFibonacciHeapMeld(b *testing.B) {
+	less := func(a, b int) bool { return a < b }
+	for i := 0; i < b.N; i++ {
+		h1, h2 := NewFibonacciHeap(less), NewFibonacciHeap(less)
+		for j := 0; j < 1000; j++ {
+			h1.Push(j)
+			h2.Push(j)
+		}
+		h1.Meld(h2)
+	}
+}
+
+func BenchmarkPriorityQueuePushPop1000(b *testing.B) {
+	pq := NewPriorityQueue(func(a, b int) bool { return a < b })
+	for i := 0; i < 1000; i++ {
+		pq.Push(i)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		pq.Push(i % 2000)
+		pq.Pop()
+	}
+}
@@ -0,0 +1,121 @@
+package queue
+
+import "container/heap"
+
+// Handle refers to an element of an IndexedPriorityQueue. It stays valid
+// until the element is popped or removed.
+type Handle[T any] struct {
+	value T
+	index int // position in the heap, or -1 once the element has left it
+}
+
+// Value returns the element the handle refers to.
+func (h *Handle[T]) Value() T { return h.value }
+
+// IndexedPriorityQueue is a priority queue whose elements can be changed or
+// removed after insertion through the Handle returned by Push.
+type IndexedPriorityQueue[T any] struct {
+	h *indexedHeap[T]
+}
+
+// NewIndexedPriorityQueue creates an indexed priority queue using the
+// provided ordering. The function less must return true when a should come
+// before b.
+func NewIndexedPriorityQueue[T any](less func(a, b T) bool) *IndexedPriorityQueue[T] {
+	return &IndexedPriorityQueue[T]{h: &indexedHeap[T]{less: less}}
+}
+
+// Push inserts an element and returns a handle to it.
+func (pq *IndexedPriorityQueue[T]) Push(v T) *Handle[T] {
+	h := &Handle[T]{value: v}
+	heap.Push(pq.h, h)
+	return h
+}
+
+// Pop removes and returns the top-priority element.
+// The boolean is false when the queue is empty.
+func (pq *IndexedPriorityQueue[T]) Pop() (T, bool) {
+	var zero T
+	if pq.h.Len() == 0 {
+		return zero, false
+	}
+	h := heap.Pop(pq.h).(*Handle[T])
+	return h.value, true
+}
+
+// Peek returns the top-priority element without removing it.
+func (pq *IndexedPriorityQueue[T]) Peek() (T, bool) {
+	var zero T
+	if pq.h.Len() == 0 {
+		return zero, false
+	}
+	return pq.h.data[0].value, true
+}
+
+// Contains reports whether the element referred to by h is in the queue.
+func (pq *IndexedPriorityQueue[T]) Contains(h *Handle[T]) bool {
+	return h != nil && h.index >= 0 && h.index < len(pq.h.data) && pq.h.data[h.index] == h
+}
+
+// Update replaces the element referred to by h with v and restores the heap
+// order in O(log n). This covers both decrease-key and increase-key. It
+// reports false, and does nothing, if h is not in the queue.
+func (pq *IndexedPriorityQueue[T]) Update(h *Handle[T], v T) bool {
+	if !pq.Contains(h) {
+		return false
+	}
+	h.value = v
+	heap.Fix(pq.h, h.index)
+	return true
+}
+
+// Remove removes the element referred to by h in O(log n). It reports
+// false if h is not in the queue.
+func (pq *IndexedPriorityQueue[T]) Remove(h *Handle[T]) bool {
+	if !pq.Contains(h) {
+		return false
+	}
+	heap.Remove(pq.h, h.index)
+	return true
+}
+
+// Len returns the number of elements.
+func (pq *IndexedPriorityQueue[T]) Len() int { return pq.h.Len() }
+
+// IsEmpty reports whether the queue is empty.
+func (pq *IndexedPriorityQueue[T]) IsEmpty() bool { return pq.h.Len() == 0 }
+
+// Clear removes all elements. Existing handles are no longer contained.
+func (pq *IndexedPriorityQueue[T]) Clear() {
+	for _, h := range pq.h.data {
+		h.index = -1
+	}
+	pq.h.data = nil
+}
+
+// internal heap of handles that keeps each handle's index up to date
+type indexedHeap[T any] struct {
+	data []*Handle[T]
+	less func(a, b T) bool
+}
+
+func (h *indexedHeap[T]) Len() int           { return len(h.data) }
+func (h *indexedHeap[T]) Less(i, j int) bool { return h.less(h.data[i].value, h.data[j].value) }
+func (h *indexedHeap[T]) Swap(i, j int) {
+	h.data[i], h.data[j] = h.data[j], h.data[i]
+	h.data[i].index = i
+	h.data[j].index = j
+}
+func (h *indexedHeap[T]) Push(x any) {
+	e := x.(*Handle[T])
+	e.index = len(h.data)
+	h.data = append(h.data, e)
+}
+func (h *indexedHeap[T]) Pop() any {
+	n := len(h.data)
+	e := h.data[n-1]
+	h.data[n-1] = nil
+	h.data = h.data[:n-1]
+	e.index = -1
+	return e
+}
@@ -0,0 +1,175 @@
+package queue
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+type task struct {
+	name     string
+	priority int
+}
+
+func byPriority(a, b task) bool { return a.priority < b.priority }
+
+func TestIndexedPushPop(t *testing.T) {
+	pq := NewIndexedPriorityQueue(func(a, b int) bool { return a < b })
+	if !pq.IsEmpty() || pq.Len() != 0 {
+		t.Error("new queue should be empty")
+	}
+	for _, v := range []int{5, 2, 8, 1, 9, 3} {
+		pq.Push(v)
+	}
+	if top, ok := pq.Peek(); !ok || top != 1 {
+		t.Errorf("Peek() = %d, %v, want 1", top, ok)
+	}
+	var got []int
+	for !pq.IsEmpty() {
+		v, _ := pq.Pop()
+		got = append(got, v)
+	}
+	if want := []int{1, 2, 3, 5, 8, 9}; !slices.Equal(got, want) {
+		t.Errorf("popped %v, want %v", got, want)
+	}
+	if _, ok := pq.Pop(); ok {
+		t.Error("Pop on empty queue should fail")
+	}
+	if _, ok := pq.Peek(); ok {
+		t.Error("Peek on empty queue should fail")
+	}
+}
+
+func TestIndexedUpdate(t *testing.T) {
+	pq := NewIndexedPriorityQueue(byPriority)
+	a := pq.Push(task{"a", 5})
+	b := pq.Push(task{"b", 3})
+	c := pq.Push(task{"c", 7})
+
+	// decrease-key
+	if !pq.Update(c, task{"c", 1}) {
+		t.Fatal("Update should succeed for a queued handle")
+	}
+	if top, _ := pq.Peek(); top.name != "c" {
+		t.Errorf("top = %s, want c after decrease-key", top.name)
+	}
+	// increase-key
+	pq.Update(c, task{"c", 10})
+	if top, _ := pq.Peek(); top.name != "b" {
+		t.Errorf("top = %s, want b after increase-key", top.name)
+	}
+	if a.Value().priority != 5 || c.Value().priority != 10 {
+		t.Error("Value should reflect the current element")
+	}
+
+	var order []string
+	for !pq.IsEmpty() {
+		v, _ := pq.Pop()
+		order = append(order, v.name)
+	}
+	if want := []string{"b", "a", "c"}; !slices.Equal(order, want) {
+		t.Errorf("order = %v, want %v", order, want)
+	}
+	if pq.Update(b, task{"b", 0}) {
+		t.Error("Update should fail once the element has been popped")
+	}
+}
+
+func TestIndexedRemoveContains(t *testing.T) {
+	pq := NewIndexedPriorityQueue(func(a, b int) bool { return a < b })
+	handles := make([]*Handle[int], 10)
+	for i := range handles {
+		handles[i] = pq.Push(i)
+	}
+
+	if !pq.Remove(handles[0]) || !pq.Remove(handles[5]) {
+		t.Fatal("Remove should succeed for queued handles")
+	}
+	if pq.Contains(handles[5]) || pq.Remove(handles[5]) {
+		t.Error("removed handle should not be contained or removable again")
+	}
+	if !pq.Contains(handles[9]) {
+		t.Error("queued handle should be contained")
+	}
+	if pq.Contains(nil) {
+		t.Error("nil handle should not be contained")
+	}
+
+	other := NewIndexedPriorityQueue(func(a, b int) bool { return a < b })
+	foreign := other.Push(1)
+	if pq.Contains(foreign) || pq.Remove(foreign) || pq.Update(foreign, 0) {
+		t.Error("handles from another queue should be rejected")
+	}
+
+	var got []int
+	for !pq.IsEmpty() {
+		v, _ := pq.Pop()
+		got = append(got, v)
+	}
+	if want := []int{1, 2, 3, 4, 6, 7, 8, 9}; !slices.Equal(got, want) {
+		t.Errorf("popped %v, want %v", got, want)
+	}
+}
+
+func TestIndexedClear(t *testing.T) {
+	pq := NewIndexedPriorityQueue(func(a, b int) bool { return a < b })
+	h := pq.Push(1)
+	pq.Push(2)
+	pq.Clear()
+	if !pq.IsEmpty() || pq.Contains(h) {
+		t.Error("Clear should empty the queue and invalidate handles")
+	}
+	pq.Push(3)
+	if pq.Contains(h) {
+		t.Error("stale handle should not match a new element")
+	}
+}
+
+func TestIndexedRandomOps(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	pq := NewIndexedPriorityQueue(func(a, b int) bool { return a < b })
+	live := make(map[*Handle[int]]bool)
+
+	for i := 0; i < 5000; i++ {
+		switch op := r.IntN(4); {
+		case op == 0 || len(live) == 0:
+			live[pq.Push(r.IntN(1000))] = true
+		default:
+			var h *Handle[int]
+			for h = range live {
+				break
+			}
+			if op == 1 {
+				pq.Update(h, r.IntN(1000))
+			} else {
+				pq.Remove(h)
+				delete(live, h)
+			}
+		}
+	}
+	if pq.Len() != len(live) {
+		t.Fatalf("Len() = %d, want %d", pq.Len(), len(live))
+	}
+	var want []int
+	for h := range live {
+		want = append(want, h.Value())
+	}
+	slices.Sort(want)
+	for i, w := range want {
+		if v, _ := pq.Pop(); v != w {
+			t.Fatalf("Pop[%d] = %d, want %d", i, v, w)
+		}
+	}
+}
+
+func BenchmarkIndexedUpdate(b *testing.B) {
+	pq := NewIndexedPriorityQueue(func(a, b int) bool { return a < b })
+	handles := make([]*Handle[int], 1000)
+	for i := range handles {
+		handles[i] = pq.Push(i)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		pq.Update(handles[i%len(handles)], i)
+	}
+}
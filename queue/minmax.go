@@ -0,0 +1,183 @@
+package queue
+
+import "math/bits"
+
+// MinMaxHeap is a double-ended priority queue: both the first and the last
+// element in priority order can be read in O(1) and removed in O(log n).
+// Nodes on even levels of the heap are no greater than their descendants
+// and nodes on odd levels are no smaller.
+type MinMaxHeap[T any] struct {
+	data []T
+	less func(a, b T) bool
+}
+
+// NewMinMaxHeap creates a min-max heap using the provided ordering.
+// The function less must return true when a should come before b; the
+// "min" end is the element that comes first.
+func NewMinMaxHeap[T any](less func(a, b T) bool) *MinMaxHeap[T] {
+	return &MinMaxHeap[T]{less: less}
+}
+
+// MinMaxHeapFromSlice creates a MinMaxHeap from a slice in O(n) using the
+// provided ordering. The slice is not modified.
+func MinMaxHeapFromSlice[T any](items []T, less func(a, b T) bool) *MinMaxHeap[T] {
+	h := &MinMaxHeap[T]{data: make([]T, len(items)), less: less}
+	copy(h.data, items)
+	for i := len(h.data)/2 - 1; i >= 0; i-- {
+		h.down(i)
+	}
+	return h
+}
+
+// PushBoth inserts an element in O(log n), after which it can be reached
+// from either end.
+func (h *MinMaxHeap[T]) PushBoth(v T) {
+	h.data = append(h.data, v)
+	h.up(len(h.data) - 1)
+}
+
+// PopMin removes and returns the first element in priority order.
+// The boolean is false when the heap is empty.
+func (h *MinMaxHeap[T]) PopMin() (T, bool) {
+	if len(h.data) == 0 {
+		var zero T
+		return zero, false
+	}
+	return h.removeAt(0), true
+}
+
+// PopMax removes and returns the last element in priority order.
+// The boolean is false when the heap is empty.
+func (h *MinMaxHeap[T]) PopMax() (T, bool) {
+	if len(h.data) == 0 {
+		var zero T
+		return zero, false
+	}
+	return h.removeAt(h.maxIndex()), true
+}
+
+// PeekMin returns the first element in priority order without removing it.
+func (h *MinMaxHeap[T]) PeekMin() (T, bool) {
+	if len(h.data) == 0 {
+		var zero T
+		return zero, false
+	}
+	return h.data[0], true
+}
+
+// PeekMax returns the last element in priority order without removing it.
+func (h *MinMaxHeap[T]) PeekMax() (T, bool) {
+	if len(h.data) == 0 {
+		var zero T
+		return zero, false
+	}
+	return h.data[h.maxIndex()], true
+}
+
+// Len returns the number of elements.
+func (h *MinMaxHeap[T]) Len() int { return len(h.data) }
+
+// IsEmpty reports whether the heap is empty.
+func (h *MinMaxHeap[T]) IsEmpty() bool { return len(h.data) == 0 }
+
+// Clear removes all elements.
+func (h *MinMaxHeap[T]) Clear() {
+	h.data = nil
+}
+
+// Clone returns a shallow copy of the heap.
+func (h *MinMaxHeap[T]) Clone() *MinMaxHeap[T] {
+	clone := &MinMaxHeap[T]{data: make([]T, len(h.data)), less: h.less}
+	copy(clone.data, h.data)
+	return clone
+}
+
+// maxIndex returns the index of the largest element of a non-empty heap:
+// the root if it has no children, otherwise the larger of its children.
+func (h *MinMaxHeap[T]) maxIndex() int {
+	switch len(h.data) {
+	case 1:
+		return 0
+	case 2:
+		return 1
+	}
+	if h.less(h.data[1], h.data[2]) {
+		return 2
+	}
+	return 1
+}
+
+func (h *MinMaxHeap[T]) removeAt(i int) T {
+	v := h.data[i]
+	last := len(h.data) - 1
+	h.data[i] = h.data[last]
+	var zero T
+	h.data[last] = zero // avoid memory leak for reference types
+	h.data = h.data[:last]
+	if i < last {
+		h.down(i)
+	}
+	return v
+}
+
+// isMinLevel reports whether index i is on an even level of the heap.
+func isMinLevel(i int) bool {
+	return bits.Len(uint(i+1))%2 == 1
+}
+
+// before reports whether a belongs above b on the given kind of level.
+func (h *MinMaxHeap[T]) before(a, b T, minLevel bool) bool {
+	if minLevel {
+		return h.less(a, b)
+	}
+	return h.less(b, a)
+}
+
+func (h *MinMaxHeap[T]) up(i int) {
+	if i == 0 {
+		return
+	}
+	minLevel := isMinLevel(i)
+	p := (i - 1) / 2
+	// If i belongs on the other kind of level, move it to its parent and
+	// continue among the parent's ancestors.
+	if h.before(h.data[p], h.data[i], minLevel) {
+		h.data[i], h.data[p] = h.data[p], h.data[i]
+		i, minLevel = p, !minLevel
+	}
+	// Bubble up through grandparents, which are on the same kind of level.
+	for i > 2 {
+		gp := ((i-1)/2 - 1) / 2
+		if !h.before(h.data[i], h.data[gp], minLevel) {
+			break
+		}
+		h.data[i], h.data[gp] = h.data[gp], h.data[i]
+		i = gp
+	}
+}
+
+func (h *MinMaxHeap[T]) down(i int) {
+	minLevel := isMinLevel(i)
+	n := len(h.data)
+	for {
+		// Find the best of i's children and grandchildren.
+		m := -1
+		for _, c := range [...]int{2*i + 1, 2*i + 2, 4*i + 3, 4*i + 4, 4*i + 5, 4*i + 6} {
+			if c < n && (m < 0 || h.before(h.data[c], h.data[m], minLevel)) {
+				m = c
+			}
+		}
+		if m < 0 || !h.before(h.data[m], h.data[i], minLevel) {
+			return
+		}
+		h.data[i], h.data[m] = h.data[m], h.data[i]
+		if m <= 2*i+2 {
+			return // a child is only best when no descendant of it beats i's old value
+		}
+		// m is a grandchild; its parent is on the other kind of level.
+		if p := (m - 1) / 2; h.before(h.data[p], h.data[m], minLevel) {
+			h.data[m], h.data[p] = h.data[p], h.data[m]
+		}
+		i = m
+	}
+}
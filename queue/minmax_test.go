@@ -0,0 +1,167 @@
+package queue
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+func TestMinMaxHeapBasic(t *testing.T) {
+	h := NewMinMaxHeap(func(a, b int) bool { return a < b })
+	if !h.IsEmpty() || h.Len() != 0 {
+		t.Error("new heap should be empty")
+	}
+	for _, f := range []func() (int, bool){h.PopMin, h.PopMax, h.PeekMin, h.PeekMax} {
+		if _, ok := f(); ok {
+			t.Error("operations on an empty heap should fail")
+		}
+	}
+
+	for _, v := range []int{5, 2, 8, 1, 9, 3, 7} {
+		h.PushBoth(v)
+	}
+	if v, _ := h.PeekMin(); v != 1 {
+		t.Errorf("PeekMin() = %d, want 1", v)
+	}
+	if v, _ := h.PeekMax(); v != 9 {
+		t.Errorf("PeekMax() = %d, want 9", v)
+	}
+
+	var got []int
+	for !h.IsEmpty() {
+		lo, _ := h.PopMin()
+		got = append(got, lo)
+		if hi, ok := h.PopMax(); ok {
+			got = append(got, hi)
+		}
+	}
+	if want := []int{1, 9, 2, 8, 3, 7, 5}; !slices.Equal(got, want) {
+		t.Errorf("alternating pops = %v, want %v", got, want)
+	}
+}
+
+func TestMinMaxHeapSmall(t *testing.T) {
+	h := NewMinMaxHeap(func(a, b int) bool { return a < b })
+	h.PushBoth(4)
+	if lo, _ := h.PeekMin(); lo != 4 {
+		t.Errorf("PeekMin() = %d", lo)
+	}
+	if hi, _ := h.PeekMax(); hi != 4 {
+		t.Errorf("PeekMax() = %d", hi)
+	}
+	h.PushBoth(2)
+	if hi, _ := h.PopMax(); hi != 4 {
+		t.Errorf("PopMax() = %d, want 4", hi)
+	}
+	if lo, _ := h.PopMax(); lo != 2 {
+		t.Errorf("PopMax() = %d, want 2", lo)
+	}
+}
+
+func TestMinMaxHeapRandom(t *testing.T) {
+	r := rand.New(rand.NewPCG(3, 4))
+	h := NewMinMaxHeap(func(a, b int) bool { return a < b })
+	var ref []int
+
+	for i := 0; i < 20000; i++ {
+		switch r.IntN(3) {
+		case 0:
+			v, ok := h.PopMin()
+			if len(ref) == 0 {
+				if ok {
+					t.Fatal("PopMin succeeded on empty heap")
+				}
+				continue
+			}
+			if v != ref[0] {
+				t.Fatalf("PopMin() = %d, want %d", v, ref[0])
+			}
+			ref = ref[1:]
+		case 1:
+			v, ok := h.PopMax()
+			if len(ref) == 0 {
+				if ok {
+					t.Fatal("PopMax succeeded on empty heap")
+				}
+				continue
+			}
+			if v != ref[len(ref)-1] {
+				t.Fatalf("PopMax() = %d, want %d", v, ref[len(ref)-1])
+			}
+			ref = ref[:len(ref)-1]
+		default:
+			for k := 0; k < 2; k++ {
+				v := r.IntN(500)
+				h.PushBoth(v)
+				i, _ := slices.BinarySearch(ref, v)
+				ref = slices.Insert(ref, i, v)
+			}
+		}
+		if h.Len() != len(ref) {
+			t.Fatalf("Len() = %d, want %d", h.Len(), len(ref))
+		}
+	}
+}
+
+func TestMinMaxHeapFromSlice(t *testing.T) {
+	r := rand.New(rand.NewPCG(5, 6))
+	items := make([]int, 1000)
+	for i := range items {
+		items[i] = r.IntN(100)
+	}
+	orig := slices.Clone(items)
+	h := MinMaxHeapFromSlice(items, func(a, b int) bool { return a < b })
+	if !slices.Equal(items, orig) {
+		t.Error("MinMaxHeapFromSlice should not modify its input")
+	}
+
+	sorted := slices.Clone(items)
+	slices.Sort(sorted)
+	lo, hi := 0, len(sorted)-1
+	for lo <= hi {
+		if v, _ := h.PopMin(); v != sorted[lo] {
+			t.Fatalf("PopMin() = %d, want %d", v, sorted[lo])
+		}
+		lo++
+		if lo > hi {
+			break
+		}
+		if v, _ := h.PopMax(); v != sorted[hi] {
+			t.Fatalf("PopMax() = %d, want %d", v, sorted[hi])
+		}
+		hi--
+	}
+}
+
+func TestMinMaxHeapCloneClear(t *testing.T) {
+	h := MinMaxHeapFromSlice([]string{"b", "d", "a", "c"}, func(a, b string) bool { return a < b })
+	c := h.Clone()
+	c.PopMin()
+	c.PopMax()
+	if h.Len() != 4 || c.Len() != 2 {
+		t.Errorf("Len() = %d and %d, want 4 and 2", h.Len(), c.Len())
+	}
+	if v, _ := c.PeekMax(); v != "c" {
+		t.Errorf("clone PeekMax() = %q, want c", v)
+	}
+	h.Clear()
+	if !h.IsEmpty() {
+		t.Error("Clear should empty the heap")
+	}
+}
+
+func BenchmarkMinMaxHeapPushPop(b *testing.B) {
+	h := NewMinMaxHeap(func(a, b int) bool { return a < b })
+	for i := 0; i < 100; i++ {
+		h.PushBoth(i)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		h.PushBoth(i % 200)
+		if i%2 == 0 {
+			h.PopMin()
+		} else {
+			h.PopMax()
+		}
+	}
+}
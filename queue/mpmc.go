@@ -0,0 +1,110 @@
+package queue
+
+import "sync/atomic"
+
+// cacheLine is the assumed CPU cache line size, used to keep the producer
+// and consumer counters from sharing a line.
+const cacheLine = 64
+
+// MPMCQueue is a bounded lock-free FIFO queue that is safe for use by any
+// number of producers and consumers. It implements Dmitry Vyukov's bounded
+// MPMC algorithm: every slot carries a sequence number telling producers
+// and consumers whether it is ready for them, so each operation needs just
+// one compare-and-swap on the shared position counter.
+//
+// Operations never block; TryEnqueue fails when the queue is full and
+// TryDequeue when it is empty.
+type MPMCQueue[T any] struct {
+	slots []mpmcSlot[T]
+	mask  uint64
+	_     [cacheLine]byte
+	head  atomic.Uint64 // next position to dequeue
+	_     [cacheLine - 8]byte
+	tail  atomic.Uint64 // next position to enqueue
+	_     [cacheLine - 8]byte
+}
+
+// A slot holding position pos is free for the producer of pos when its
+// sequence equals pos, and holds a value for the consumer of pos when its
+// sequence equals pos+1.
+type mpmcSlot[T any] struct {
+	seq   atomic.Uint64
+	value T
+}
+
+// NewMPMCQueue creates an empty MPMCQueue. The capacity is rounded up to a
+// power of two, and to at least 2. It panics if capacity is less than 1.
+func NewMPMCQueue[T any](capacity int) *MPMCQueue[T] {
+	if capacity < 1 {
+		panic("queue: MPMCQueue capacity must be at least 1")
+	}
+	n := 2
+	for n < capacity {
+		n <<= 1
+	}
+	q := &MPMCQueue[T]{slots: make([]mpmcSlot[T], n), mask: uint64(n - 1)}
+	for i := range q.slots {
+		q.slots[i].seq.Store(uint64(i))
+	}
+	return q
+}
+
+// TryEnqueue adds v to the back of the queue. It reports false if the
+// queue is full.
+func (q *MPMCQueue[T]) TryEnqueue(v T) bool {
+	pos := q.tail.Load()
+	for {
+		slot := &q.slots[pos&q.mask]
+		seq := slot.seq.Load()
+		switch diff := int64(seq - pos); {
+		case diff == 0:
+			if q.tail.CompareAndSwap(pos, pos+1) {
+				slot.value = v
+				slot.seq.Store(pos + 1)
+				return true
+			}
+		case diff < 0:
+			// The slot still holds the value from one lap ago.
+			return false
+		}
+		pos = q.tail.Load()
+	}
+}
+
+// TryDequeue removes and returns the front element.
+// The boolean is false when the queue is empty.
+func (q *MPMCQueue[T]) TryDequeue() (T, bool) {
+	pos := q.head.Load()
+	for {
+		slot := &q.slots[pos&q.mask]
+		seq := slot.seq.Load()
+		switch diff := int64(seq - (pos + 1)); {
+		case diff == 0:
+			if q.head.CompareAndSwap(pos, pos+1) {
+				v := slot.value
+				var zero T
+				slot.value = zero // avoid memory leak for reference types
+				slot.seq.Store(pos + q.mask + 1)
+				return v, true
+			}
+		case diff < 0:
+			// No producer has filled the slot yet.
+			var zero T
+			return zero, false
+		}
+		pos = q.head.Load()
+	}
+}
+
+// Len returns the number of elements in the queue. Under concurrent use
+// the result is only a snapshot and may be stale by the time it returns.
+func (q *MPMCQueue[T]) Len() int {
+	// Load head first: tail never falls behind it, so the difference
+	// cannot underflow.
+	head := q.head.Load()
+	tail := q.tail.Load()
+	return int(min(tail-head, uint64(len(q.slots))))
+}
+
+// Cap returns the capacity of the queue.
+func (q *MPMCQueue[T]) Cap() int { return len(q.slots) }
@@ -0,0 +1,175 @@
+package queue
+
+import (
+	"runtime"
+	"sync"
+	"testing"
+)
+
+func TestMPMCQueueBasic(t *testing.T) {
+	q := NewMPMCQueue[int](5)
+	if q.Cap() != 8 {
+		t.Errorf("Cap() = %d, want 8 (rounded up)", q.Cap())
+	}
+	if _, ok := q.TryDequeue(); ok {
+		t.Error("TryDequeue on empty queue should fail")
+	}
+	for i := 0; i < 8; i++ {
+		if !q.TryEnqueue(i) {
+			t.Fatalf("TryEnqueue(%d) failed below capacity", i)
+		}
+	}
+	if q.TryEnqueue(8) {
+		t.Error("TryEnqueue on full queue should fail")
+	}
+	if q.Len() != 8 {
+		t.Errorf("Len() = %d, want 8", q.Len())
+	}
+	// Several laps around the ring keep FIFO order.
+	for i := 0; i < 100; i++ {
+		v, ok := q.TryDequeue()
+		if !ok || v != i {
+			t.Fatalf("TryDequeue() = %d, %v, want %d", v, ok, i)
+		}
+		if !q.TryEnqueue(i + 8) {
+			t.Fatalf("TryEnqueue(%d) failed after a dequeue", i+8)
+		}
+	}
+}
+
+func TestMPMCQueueSmallCapacity(t *testing.T) {
+	q := NewMPMCQueue[string](1)
+	if q.Cap() != 2 {
+		t.Errorf("Cap() = %d, want 2", q.Cap())
+	}
+	for lap := 0; lap < 5; lap++ {
+		q.TryEnqueue("a")
+		q.TryEnqueue("b")
+		if q.TryEnqueue("c") {
+			t.Fatal("queue of capacity 2 accepted a third element")
+		}
+		a, _ := q.TryDequeue()
+		b, _ := q.TryDequeue()
+		if a != "a" || b != "b" || q.Len() != 0 {
+			t.Fatalf("lap %d: got %q %q", lap, a, b)
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("zero capacity should panic")
+		}
+	}()
+	NewMPMCQueue[int](0)
+}
+
+func TestMPMCQueueStress(t *testing.T) {
+	const producers, consumers, perProducer = 4, 4, 5000
+	q := NewMPMCQueue[int](64)
+
+	var wg sync.WaitGroup
+	for p := 0; p < producers; p++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < perProducer; i++ {
+				for !q.TryEnqueue(p*perProducer + i) {
+					runtime.Gosched()
+				}
+			}
+		}()
+	}
+
+	seen := make([][]int, consumers)
+	var remaining sync.WaitGroup
+	remaining.Add(producers * perProducer)
+	done := make(chan struct{})
+	for c := 0; c < consumers; c++ {
+		go func() {
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				if v, ok := q.TryDequeue(); ok {
+					seen[c] = append(seen[c], v)
+					remaining.Done()
+				} else {
+					runtime.Gosched()
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	remaining.Wait()
+	close(done)
+
+	count := make([]int, producers*perProducer)
+	for c, values := range seen {
+		// Values from one producer must reach each consumer in order.
+		last := make([]int, producers)
+		for p := range last {
+			last[p] = -1
+		}
+		for _, v := range values {
+			count[v]++
+			p := v / perProducer
+			if v <= last[p] {
+				t.Fatalf("consumer %d saw %d after %d", c, v, last[p])
+			}
+			last[p] = v
+		}
+	}
+	for v, n := range count {
+		if n != 1 {
+			t.Fatalf("value %d dequeued %d times", v, n)
+		}
+	}
+	if q.Len() != 0 {
+		t.Errorf("Len() = %d after draining", q.Len())
+	}
+}
+
+// Benchmarks compare the lock-free queue with a mutex-protected Queue and
+// a buffered channel under parallel enqueue/dequeue pairs.
+
+func BenchmarkMPMCQueue(b *testing.B) {
+	q := NewMPMCQueue[int](1024)
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			for !q.TryEnqueue(1) {
+			}
+			for {
+				if _, ok := q.TryDequeue(); ok {
+					break
+				}
+			}
+		}
+	})
+}
+
+func BenchmarkMutexQueue(b *testing.B) {
+	var mu sync.Mutex
+	q := NewQueue[int]()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			mu.Lock()
+			q.Enqueue(1)
+			mu.Unlock()
+			mu.Lock()
+			q.Dequeue()
+			mu.Unlock()
+		}
+	})
+}
+
+func BenchmarkChannelQueue(b *testing.B) {
+	ch := make(chan int, 1024)
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			ch <- 1
+			<-ch
+		}
+	})
+}
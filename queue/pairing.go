@@ -0,0 +1,166 @@
+package queue
+
+// PairingNode is an element of a PairingHeap, returned by Push for use with
+// DecreaseKey.
+type PairingNode[T any] struct {
+	value   T
+	child   *PairingNode[T] // leftmost child
+	sibling *PairingNode[T] // next sibling to the right
+	prev    *PairingNode[T] // left sibling, or parent for a leftmost child
+	popped  bool
+}
+
+// Value returns the element stored in the node.
+func (n *PairingNode[T]) Value() T { return n.value }
+
+// PairingHeap is a meldable priority queue. Push, Meld and DecreaseKey take
+// O(1) amortised time and Pop takes O(log n) amortised time.
+type PairingHeap[T any] struct {
+	root *PairingNode[T]
+	size int
+	less func(a, b T) bool
+}
+
+// NewPairingHeap creates a pairing heap using the provided ordering.
+// The function less must return true when a should come before b.
+func NewPairingHeap[T any](less func(a, b T) bool) *PairingHeap[T] {
+	return &PairingHeap[T]{less: less}
+}
+
+// Push inserts an element and returns its node.
+func (h *PairingHeap[T]) Push(v T) *PairingNode[T] {
+	n := &PairingNode[T]{value: v}
+	h.root = h.link(h.root, n)
+	h.size++
+	return n
+}
+
+// Pop removes and returns the top-priority element.
+// The boolean is false when the heap is empty.
+func (h *PairingHeap[T]) Pop() (T, bool) {
+	if h.root == nil {
+		var zero T
+		return zero, false
+	}
+	r := h.root
+	h.root = h.mergePairs(r.child)
+	r.child = nil
+	r.popped = true
+	h.size--
+	return r.value, true
+}
+
+// Peek returns the top-priority element without removing it.
+func (h *PairingHeap[T]) Peek() (T, bool) {
+	if h.root == nil {
+		var zero T
+		return zero, false
+	}
+	return h.root.value, true
+}
+
+// Len returns the number of elements.
+func (h *PairingHeap[T]) Len() int { return h.size }
+
+// IsEmpty reports whether the heap is empty.
+func (h *PairingHeap[T]) IsEmpty() bool { return h.size == 0 }
+
+// Clear removes all elements in O(n). Nodes returned by earlier calls to
+// Push are invalidated, so DecreaseKey reports false for them.
+func (h *PairingHeap[T]) Clear() {
+	for stack := []*PairingNode[T]{h.root}; len(stack) > 0; {
+		n := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if n == nil {
+			continue
+		}
+		stack = append(stack, n.child, n.sibling)
+		n.child, n.sibling, n.prev = nil, nil, nil
+		n.popped = true
+	}
+	h.root = nil
+	h.size = 0
+}
+
+// Meld moves every element of other into h in O(1), leaving other empty.
+// Nodes of other remain valid and now belong to h. Both heaps must use the
+// same ordering.
+func (h *PairingHeap[T]) Meld(other *PairingHeap[T]) {
+	if other == h {
+		return
+	}
+	h.root = h.link(h.root, other.root)
+	h.size += other.size
+	other.root, other.size = nil, 0
+}
+
+// DecreaseKey replaces the element of n, which must belong to h, with v,
+// which must not come after the current element. It reports false, and
+// does nothing, if n has been popped or v would come after the current
+// element.
+func (h *PairingHeap[T]) DecreaseKey(n *PairingNode[T], v T) bool {
+	if n.popped || h.less(n.value, v) {
+		return false
+	}
+	n.value = v
+	if n == h.root {
+		return true
+	}
+	// Cut the subtree rooted at n and link it with the root.
+	if n.prev.child == n {
+		n.prev.child = n.sibling
+	} else {
+		n.prev.sibling = n.sibling
+	}
+	if n.sibling != nil {
+		n.sibling.prev = n.prev
+	}
+	n.prev, n.sibling = nil, nil
+	h.root = h.link(h.root, n)
+	return true
+}
+
+// link makes the later of two roots the leftmost child of the other and
+// returns the new root. Either may be nil.
+func (h *PairingHeap[T]) link(a, b *PairingNode[T]) *PairingNode[T] {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	if h.less(b.value, a.value) {
+		a, b = b, a
+	}
+	b.prev = a
+	b.sibling = a.child
+	if a.child != nil {
+		a.child.prev = b
+	}
+	a.child = b
+	return a
+}
+
+// mergePairs combines a list of siblings into one tree with the standard
+// two passes: link pairs from left to right, then fold the results from
+// right to left.
+func (h *PairingHeap[T]) mergePairs(first *PairingNode[T]) *PairingNode[T] {
+	var pairs []*PairingNode[T]
+	for a := first; a != nil; {
+		b := a.sibling
+		a.prev, a.sibling = nil, nil
+		if b == nil {
+			pairs = append(pairs, a)
+			break
+		}
+		next := b.sibling
+		b.prev, b.sibling = nil, nil
+		pairs = append(pairs, h.link(a, b))
+		a = next
+	}
+	var root *PairingNode[T]
+	for i := len(pairs) - 1; i >= 0; i-- {
+		root = h.link(pairs[i], root)
+	}
+	return root
+}
@@ -0,0 +1,221 @@
+package queue
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+// meldableHeap is the surface shared by PairingHeap and FibonacciHeap, so
+// both can be checked by the same tests.
+type meldableHeap[N any] interface {
+	Push(v int) N
+	Pop() (int, bool)
+	Peek() (int, bool)
+	Len() int
+	IsEmpty() bool
+	Clear()
+	DecreaseKey(n N, v int) bool
+}
+
+func checkHeapSorts[N any](t *testing.T, h meldableHeap[N]) {
+	t.Helper()
+	if _, ok := h.Pop(); ok {
+		t.Error("Pop on empty heap should fail")
+	}
+	if _, ok := h.Peek(); ok {
+		t.Error("Peek on empty heap should fail")
+	}
+	r := rand.New(rand.NewPCG(1, 2))
+	want := make([]int, 2000)
+	for i := range want {
+		want[i] = r.IntN(500)
+		h.Push(want[i])
+	}
+	slices.Sort(want)
+	if h.Len() != len(want) {
+		t.Fatalf("Len() = %d, want %d", h.Len(), len(want))
+	}
+	for i, w := range want {
+		if p, _ := h.Peek(); p != w {
+			t.Fatalf("Peek() = %d, want %d", p, w)
+		}
+		if v, _ := h.Pop(); v != w {
+			t.Fatalf("Pop[%d] = %d, want %d", i, v, w)
+		}
+	}
+	if !h.IsEmpty() {
+		t.Error("heap should be empty")
+	}
+}
+
+// idBits is the number of low bits checkDecreaseKey reserves for node indexes.
+const idBits = 16
+
+func checkDecreaseKey[N interface{ Value() int }](t *testing.T, h meldableHeap[N]) {
+	t.Helper()
+	r := rand.New(rand.NewPCG(3, 4))
+	nodes := make([]N, 0, 3000)
+	live := make(map[int]N) // live nodes by index into nodes
+
+	for step := 0; step < 20000; step++ {
+		switch op := r.IntN(5); {
+		case op <= 1 || len(live) == 0:
+			// The low bits hold the node's index, and decreases keep them,
+			// so live values are distinct and Pop identifies its node.
+			n := h.Push(r.IntN(100000)<<idBits | len(nodes))
+			live[len(nodes)] = n
+			nodes = append(nodes, n)
+		case op <= 3:
+			for _, n := range live {
+				v := n.Value() - r.IntN(1000)<<idBits
+				if !h.DecreaseKey(n, v) || n.Value() != v {
+					t.Fatalf("DecreaseKey(%d) failed", v)
+				}
+				break
+			}
+		default:
+			v, _ := h.Pop()
+			best := -1
+			for i, n := range live {
+				if best < 0 || n.Value() < live[best].Value() {
+					best = i
+				}
+			}
+			if live[best].Value() != v {
+				t.Fatalf("Pop() = %d, want %d", v, live[best].Value())
+			}
+			delete(live, best)
+		}
+	}
+	if h.Len() != len(live) {
+		t.Fatalf("Len() = %d, want %d", h.Len(), len(live))
+	}
+}
+
+func checkClearInvalidates[N any](t *testing.T, h meldableHeap[N]) {
+	t.Helper()
+	var nodes []N
+	for v := 0; v < 50; v++ {
+		nodes = append(nodes, h.Push(v+5))
+	}
+	h.Pop() // build some tree structure first
+	h.Clear()
+	h.Push(100)
+	for i, n := range nodes {
+		if h.DecreaseKey(n, 1) {
+			t.Fatalf("DecreaseKey on node %d after Clear should fail", i)
+		}
+	}
+	if v, _ := h.Peek(); v != 100 || h.Len() != 1 {
+		t.Errorf("after Clear and Push: Peek() = %d, Len() = %d; want 100, 1", v, h.Len())
+	}
+}
+
+func TestPairingHeap(t *testing.T) {
+	checkHeapSorts[*PairingNode[int]](t, NewPairingHeap(func(a, b int) bool { return a < b }))
+}
+
+func TestPairingHeapDecreaseKey(t *testing.T) {
+	h := NewPairingHeap(func(a, b int) bool { return a < b })
+	a := h.Push(10)
+	b := h.Push(20)
+	c := h.Push(30)
+	if h.DecreaseKey(b, 25) {
+		t.Error("DecreaseKey to a later value should fail")
+	}
+	if !h.DecreaseKey(c, 5) {
+		t.Fatal("DecreaseKey should succeed")
+	}
+	if v, _ := h.Peek(); v != 5 {
+		t.Errorf("Peek() = %d, want 5", v)
+	}
+	h.Pop()
+	if h.DecreaseKey(c, 1) {
+		t.Error("DecreaseKey on a popped node should fail")
+	}
+	if !h.DecreaseKey(a, 10) || a.Value() != 10 {
+		t.Error("DecreaseKey to an equal value should succeed")
+	}
+
+	checkDecreaseKey[*PairingNode[int]](t, NewPairingHeap(func(a, b int) bool { return a < b }))
+}
+
+func TestPairingHeapClear(t *testing.T) {
+	checkClearInvalidates[*PairingNode[int]](t, NewPairingHeap(func(a, b int) bool { return a < b }))
+}
+
+func TestPairingHeapMeld(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+	h1, h2 := NewPairingHeap(less), NewPairingHeap(less)
+	for i := 0; i < 10; i += 2 {
+		h1.Push(i)
+	}
+	var odd []*PairingNode[int]
+	for i := 1; i < 10; i += 2 {
+		odd = append(odd, h2.Push(i))
+	}
+
+	h1.Meld(h2)
+	h1.Meld(h1)
+	h1.Meld(NewPairingHeap(less))
+	if h1.Len() != 10 || !h2.IsEmpty() {
+		t.Fatalf("after Meld: Len() = %d and %d", h1.Len(), h2.Len())
+	}
+	// Nodes from the melded heap keep working in their new heap.
+	h1.DecreaseKey(odd[4], -1)
+	want := []int{-1, 0, 1, 2, 3, 4, 5, 6, 7, 8}
+	for _, w := range want {
+		if v, _ := h1.Pop(); v != w {
+			t.Fatalf("Pop() = %d, want %d", v, w)
+		}
+	}
+
+	h2.Push(42)
+	h2.Clear()
+	if !h2.IsEmpty() {
+		t.Error("Clear should empty the heap")
+	}
+}
+
+func BenchmarkPairingHeapPushPop(b *testing.B) {
+	h := NewPairingHeap(func(a, b int) bool { return a < b })
+	for i := 0; i < 1000; i++ {
+		h.Push(i)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		h.Push(i % 2000)
+		h.Pop()
+	}
+}
+
+// Building two 1000-element heaps and merging them: the meld is O(1) for
+// the meldable heaps and a pop/push loop for PriorityQueue.
+
+func BenchmarkPairingHeapMeld(b *testing.B) {
+	less := func(a, b int) bool { return a < b }
+	for i := 0; i < b.N; i++ {
+		h1, h2 := NewPairingHeap(less), NewPairingHeap(less)
+		for j := 0; j < 1000; j++ {
+			h1.Push(j)
+			h2.Push(j)
+		}
+		h1.Meld(h2)
+	}
+}
+
+func BenchmarkPriorityQueueMerge(b *testing.B) {
+	less := func(a, b int) bool { return a < b }
+	for i := 0; i < b.N; i++ {
+		pq1, pq2 := NewPriorityQueue(less), NewPriorityQueue(less)
+		for j := 0; j < 1000; j++ {
+			pq1.Push(j)
+			pq2.Push(j)
+		}
+		for !pq2.IsEmpty() {
+			v, _ := pq2.Pop()
+			pq1.Push(v)
+		}
+	}
+}
@@ -1,6 +1,9 @@
 package queue
 
-import "container/heap"
+import (
+	"container/heap"
+	"iter"
+)
 
 // PriorityQueue is a heap-backed priority queue where lower priority values
 // come out first if less(a,b) returns true when a has higher priority than b.
@@ -16,6 +19,15 @@ func NewPriorityQueue[T any](less func(a, b T) bool) *PriorityQueue[T] {
 	return &PriorityQueue[T]{h: gh}
 }
 
+// NewStablePriorityQueue creates a priority queue that pops elements of
+// equal priority in the order they were pushed. Elements a and b are equal
+// when neither less(a, b) nor less(b, a) holds.
+func NewStablePriorityQueue[T any](less func(a, b T) bool) *PriorityQueue[T] {
+	gh := &genericHeap[T]{less: less, stable: true}
+	heap.Init(gh)
+	return &PriorityQueue[T]{h: gh}
+}
+
 // Push inserts an element into the queue.
 func (pq *PriorityQueue[T]) Push(v T) {
 	heap.Push(pq.h, v)
@@ -50,27 +62,83 @@ func (pq *PriorityQueue[T]) IsEmpty() bool { return pq.h.Len() == 0 }
 // Clear removes all elements.
 func (pq *PriorityQueue[T]) Clear() {
 	pq.h.data = nil
+	pq.h.seq = nil
 }
 
 // PriorityQueueFromSlice creates a PriorityQueue from a slice using the provided ordering.
 // The function less must return true when a should come before b.
+// The heap is built in O(n); the slice is not modified.
 func PriorityQueueFromSlice[T any](items []T, less func(a, b T) bool) *PriorityQueue[T] {
-	pq := NewPriorityQueue[T](less)
-	for _, item := range items {
-		pq.Push(item)
+	gh := &genericHeap[T]{data: make([]T, len(items)), less: less}
+	copy(gh.data, items)
+	heap.Init(gh)
+	return &PriorityQueue[T]{h: gh}
+}
+
+// All returns an iterator over the elements in priority order that leaves
+// the queue unchanged. Yielding the first k elements takes O(k log k). The
+// queue must not be modified during iteration.
+func (pq *PriorityQueue[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		if pq.h.Len() == 0 {
+			return
+		}
+		// The next element in order is always the best of the heap nodes
+		// whose parents have already been yielded.
+		frontier := NewPriorityQueue(pq.h.Less)
+		frontier.Push(0)
+		for !frontier.IsEmpty() {
+			i, _ := frontier.Pop()
+			if !yield(pq.h.data[i]) {
+				return
+			}
+			for _, c := range [2]int{2*i + 1, 2*i + 2} {
+				if c < pq.h.Len() {
+					frontier.Push(c)
+				}
+			}
+		}
+	}
+}
+
+// ToSortedSlice returns the elements in priority order without modifying
+// the queue.
+func (pq *PriorityQueue[T]) ToSortedSlice() []T {
+	out := make([]T, 0, pq.h.Len())
+	for v := range pq.All() {
+		out = append(out, v)
+	}
+	return out
+}
+
+// Drain returns an iterator that pops and yields elements in priority order
+// until the queue is empty. If iteration stops early, the remaining
+// elements stay in the queue.
+func (pq *PriorityQueue[T]) Drain() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for pq.h.Len() > 0 {
+			if !yield(heap.Pop(pq.h).(T)) {
+				return
+			}
+		}
 	}
-	return pq
 }
 
 // Clone returns a shallow copy of the priority queue.
 func (pq *PriorityQueue[T]) Clone() *PriorityQueue[T] {
 	clone := &PriorityQueue[T]{
 		h: &genericHeap[T]{
-			data: make([]T, len(pq.h.data)),
-			less: pq.h.less,
+			data:   make([]T, len(pq.h.data)),
+			less:   pq.h.less,
+			stable: pq.h.stable,
+			next:   pq.h.next,
 		},
 	}
 	copy(clone.h.data, pq.h.data)
+	if pq.h.stable {
+		clone.h.seq = make([]uint64, len(pq.h.seq))
+		copy(clone.h.seq, pq.h.seq)
+	}
 	return clone
 }
 
@@ -78,15 +146,40 @@ func (pq *PriorityQueue[T]) Clone() *PriorityQueue[T] {
 type genericHeap[T any] struct {
 	data []T
 	less func(a, b T) bool
+
+	// In stable mode seq holds the insertion number of each element of
+	// data and breaks ties between equal elements.
+	stable bool
+	seq    []uint64
+	next   uint64
 }
 
-func (h *genericHeap[T]) Len() int           { return len(h.data) }
-func (h *genericHeap[T]) Less(i, j int) bool { return h.less(h.data[i], h.data[j]) }
-func (h *genericHeap[T]) Swap(i, j int)      { h.data[i], h.data[j] = h.data[j], h.data[i] }
-func (h *genericHeap[T]) Push(x any)         { h.data = append(h.data, x.(T)) }
+func (h *genericHeap[T]) Len() int { return len(h.data) }
+func (h *genericHeap[T]) Less(i, j int) bool {
+	if h.less(h.data[i], h.data[j]) {
+		return true
+	}
+	return h.stable && !h.less(h.data[j], h.data[i]) && h.seq[i] < h.seq[j]
+}
+func (h *genericHeap[T]) Swap(i, j int) {
+	h.data[i], h.data[j] = h.data[j], h.data[i]
+	if h.stable {
+		h.seq[i], h.seq[j] = h.seq[j], h.seq[i]
+	}
+}
+func (h *genericHeap[T]) Push(x any) {
+	h.data = append(h.data, x.(T))
+	if h.stable {
+		h.seq = append(h.seq, h.next)
+		h.next++
+	}
+}
 func (h *genericHeap[T]) Pop() any {
 	n := len(h.data)
 	v := h.data[n-1]
 	h.data = h.data[:n-1]
+	if h.stable {
+		h.seq = h.seq[:n-1]
+	}
 	return v
 }
@@ -441,3 +441,231 @@ func BenchmarkPushPop(b *testing.B) {
 	}
 }
 
+func TestStablePriorityQueue(t *testing.T) {
+	type job struct {
+		priority int
+		id       int
+	}
+	pq := NewStablePriorityQueue(func(a, b job) bool { return a.priority < b.priority })
+
+	// Thousands of ties across three priority levels, pushed interleaved.
+	const n = 3000
+	for i := 0; i < n; i++ {
+		pq.Push(job{priority: i % 3, id: i})
+	}
+	for p := 0; p < 3; p++ {
+		for i := p; i < n; i += 3 {
+			got, ok := pq.Pop()
+			if !ok {
+				t.Fatal("queue ran out early")
+			}
+			if got.priority != p || got.id != i {
+				t.Fatalf("Pop() = %+v, want {priority:%d id:%d}", got, p, i)
+			}
+		}
+	}
+	if !pq.IsEmpty() {
+		t.Error("queue should be empty")
+	}
+}
+
+func TestStablePriorityQueueInterleaved(t *testing.T) {
+	type job struct {
+		priority int
+		id       int
+	}
+	pq := NewStablePriorityQueue(func(a, b job) bool { return a.priority < b.priority })
+
+	// Interleave pushes and pops; within each priority, jobs must come out
+	// in push order, so popped ids only increase.
+	last := map[int]int{0: -1, 1: -1}
+	popped := 0
+	check := func() {
+		v, _ := pq.Pop()
+		if v.id <= last[v.priority] {
+			t.Fatalf("priority %d: popped id %d after %d", v.priority, v.id, last[v.priority])
+		}
+		last[v.priority] = v.id
+		popped++
+	}
+	id := 0
+	for round := 0; round < 500; round++ {
+		for k := 0; k < 5; k++ {
+			pq.Push(job{priority: (round + k) % 2, id: id})
+			id++
+		}
+		for k := 0; k < 3; k++ {
+			check()
+		}
+	}
+	for !pq.IsEmpty() {
+		check()
+	}
+	if popped != 2500 {
+		t.Fatalf("popped %d jobs, want 2500", popped)
+	}
+}
+
+func TestStablePriorityQueueCloneClear(t *testing.T) {
+	pq := NewStablePriorityQueue(func(a, b [2]int) bool { return a[0] < b[0] })
+	for i := 0; i < 10; i++ {
+		pq.Push([2]int{0, i})
+	}
+	clone := pq.Clone()
+	clone.Push([2]int{0, 10})
+	for i := 0; i <= 10; i++ {
+		if v, _ := clone.Pop(); v[1] != i {
+			t.Fatalf("clone Pop() = %v, want id %d", v, i)
+		}
+	}
+	if pq.Len() != 10 {
+		t.Errorf("original len = %d, want 10", pq.Len())
+	}
+
+	pq.Clear()
+	pq.Push([2]int{0, 1})
+	pq.Push([2]int{0, 2})
+	if v, _ := pq.Pop(); v[1] != 1 {
+		t.Errorf("after Clear, Pop() = %v, want id 1", v)
+	}
+}
+
+func TestStablePriorityQueueStrings(t *testing.T) {
+	pq := NewStablePriorityQueue(func(a, b string) bool { return len(a) < len(b) })
+	for _, s := range []string{"ccc", "a", "bb", "b", "aa", "c"} {
+		pq.Push(s)
+	}
+	want := []string{"a", "b", "c", "bb", "aa", "ccc"}
+	for _, w := range want {
+		if got, _ := pq.Pop(); got != w {
+			t.Errorf("Pop() = %q, want %q", got, w)
+		}
+	}
+}
+
+func BenchmarkStablePushPop(b *testing.B) {
+	pq := NewStablePriorityQueue(func(a, b int) bool { return a < b })
+	for i := 0; i < 100; i++ {
+		pq.Push(i % 10)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		pq.Push(i % 10)
+		pq.Pop()
+	}
+}
+
+func TestPriorityQueueFromSliceHeapify(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+	input := make([]int, 1000)
+	for i := range input {
+		input[i] = (i * 7919) % 1000
+	}
+	orig := append([]int(nil), input...)
+	pq := PriorityQueueFromSlice(input, less)
+
+	for i := range input {
+		if input[i] != orig[i] {
+			t.Fatal("PriorityQueueFromSlice should not modify its input")
+		}
+	}
+	for i := 0; i < 1000; i++ {
+		if v, _ := pq.Pop(); v != i {
+			t.Fatalf("Pop[%d] = %d", i, v)
+		}
+	}
+	if pq := PriorityQueueFromSlice(nil, less); !pq.IsEmpty() {
+		t.Error("queue from nil slice should be empty")
+	}
+}
+
+func TestPriorityQueueAll(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+	pq := PriorityQueueFromSlice([]int{5, 2, 8, 1, 9, 3, 7, 2}, less)
+
+	var got []int
+	for v := range pq.All() {
+		got = append(got, v)
+	}
+	want := []int{1, 2, 2, 3, 5, 7, 8, 9}
+	if len(got) != len(want) {
+		t.Fatalf("All() yielded %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("All() yielded %v, want %v", got, want)
+		}
+	}
+	if pq.Len() != 8 {
+		t.Errorf("All should not modify the queue, Len() = %d", pq.Len())
+	}
+
+	// Stopping early.
+	var first []int
+	for v := range pq.All() {
+		first = append(first, v)
+		if len(first) == 3 {
+			break
+		}
+	}
+	if len(first) != 3 || first[2] != 2 {
+		t.Errorf("first three = %v, want [1 2 2]", first)
+	}
+
+	for range NewPriorityQueue(less).All() {
+		t.Error("All on an empty queue should yield nothing")
+	}
+}
+
+func TestPriorityQueueAllStable(t *testing.T) {
+	pq := NewStablePriorityQueue(func(a, b [2]int) bool { return a[0] < b[0] })
+	for i := 0; i < 500; i++ {
+		pq.Push([2]int{i % 2, i})
+	}
+	sorted := pq.ToSortedSlice()
+	clone := pq.Clone()
+	for i, v := range sorted {
+		if want, _ := clone.Pop(); v != want {
+			t.Fatalf("ToSortedSlice()[%d] = %v, want %v", i, v, want)
+		}
+	}
+	if pq.Len() != 500 {
+		t.Errorf("ToSortedSlice should not modify the queue, Len() = %d", pq.Len())
+	}
+}
+
+func TestPriorityQueueDrain(t *testing.T) {
+	pq := PriorityQueueFromSlice([]string{"c", "a", "d", "b"}, func(a, b string) bool { return a < b })
+
+	var got []string
+	for v := range pq.Drain() {
+		got = append(got, v)
+		if v == "b" {
+			break
+		}
+	}
+	if len(got) != 2 || got[0] != "a" || got[1] != "b" {
+		t.Errorf("drained %v, want [a b]", got)
+	}
+	if pq.Len() != 2 {
+		t.Errorf("stopping early should keep the rest, Len() = %d", pq.Len())
+	}
+	for v := range pq.Drain() {
+		got = append(got, v)
+	}
+	if len(got) != 4 || got[3] != "d" || !pq.IsEmpty() {
+		t.Errorf("drained %v, Len() = %d", got, pq.Len())
+	}
+}
+
+func BenchmarkPriorityQueueFromSlice(b *testing.B) {
+	items := make([]int, 10000)
+	for i := range items {
+		items[i] = (i * 7919) % 10000
+	}
+	less := func(a, b int) bool { return a < b }
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		PriorityQueueFromSlice(items, less)
+	}
+}
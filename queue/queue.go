@@ -2,7 +2,8 @@
 //
 // ⚠️  NOT THREAD-SAFE
 // This implementation is not safe for concurrent access.
-// Wrap with external synchronization (sync.Mutex) if needed.
+// Wrap with external synchronization (sync.Mutex) if needed, or use
+// BlockingQueue for producer/consumer pipelines.
 package queue
 
 // Queue is a generic FIFO queue implemented as a ring buffer.
@@ -0,0 +1,173 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrFull is returned by RingBuffer.Push when the buffer is full and its
+// policy is OverflowReject.
+var ErrFull = errors.New("queue: full")
+
+// OverflowPolicy decides what a RingBuffer does with a push when it is full.
+type OverflowPolicy int
+
+const (
+	// OverflowReject refuses the new element and returns ErrFull.
+	OverflowReject OverflowPolicy = iota
+	// OverflowOverwriteOldest discards the front element to make room.
+	OverflowOverwriteOldest
+	// OverflowDropNewest silently discards the new element.
+	OverflowDropNewest
+	// OverflowBlock waits until an element is popped.
+	OverflowBlock
+)
+
+func (p OverflowPolicy) String() string {
+	switch p {
+	case OverflowReject:
+		return "Reject"
+	case OverflowOverwriteOldest:
+		return "OverwriteOldest"
+	case OverflowDropNewest:
+		return "DropNewest"
+	case OverflowBlock:
+		return "Block"
+	}
+	return "OverflowPolicy(?)"
+}
+
+// RingBuffer is a fixed-capacity FIFO buffer that is safe for concurrent
+// use. What happens when pushing into a full buffer is set by its
+// OverflowPolicy; every element lost to overflow is counted by Dropped.
+type RingBuffer[T any] struct {
+	mu       sync.Mutex
+	d        Deque[T] // buffer preallocated to capacity, so it never grows
+	capacity int
+	policy   OverflowPolicy
+	dropped  uint64
+	notFull  cond
+}
+
+// NewRingBuffer creates an empty RingBuffer holding at most capacity
+// elements. It panics if capacity is less than 1.
+func NewRingBuffer[T any](capacity int, policy OverflowPolicy) *RingBuffer[T] {
+	if capacity < 1 {
+		panic("queue: RingBuffer capacity must be at least 1")
+	}
+	return &RingBuffer[T]{
+		d:        Deque[T]{buf: make([]T, capacity)},
+		capacity: capacity,
+		policy:   policy,
+	}
+}
+
+// Push adds v to the back of the buffer. If the buffer is full the outcome
+// depends on the policy: OverflowReject returns ErrFull, OverflowBlock
+// waits for space, and the other policies return nil after dropping an
+// element.
+func (r *RingBuffer[T]) Push(v T) error {
+	return r.PushContext(context.Background(), v)
+}
+
+// PushContext is like Push but returns ctx.Err() if ctx is done while
+// waiting for space under OverflowBlock.
+func (r *RingBuffer[T]) PushContext(ctx context.Context, v T) error {
+	r.mu.Lock()
+	for r.d.Len() == r.capacity {
+		switch r.policy {
+		case OverflowReject:
+			r.dropped++
+			r.mu.Unlock()
+			return ErrFull
+		case OverflowDropNewest:
+			r.dropped++
+			r.mu.Unlock()
+			return nil
+		case OverflowOverwriteOldest:
+			r.d.PopFront()
+			r.dropped++
+		default:
+			ready := r.notFull.wait()
+			r.mu.Unlock()
+			select {
+			case <-ready:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+			r.mu.Lock()
+		}
+	}
+	r.d.PushBack(v)
+	r.mu.Unlock()
+	return nil
+}
+
+// Pop removes and returns the front element.
+// The boolean is false when the buffer is empty.
+func (r *RingBuffer[T]) Pop() (T, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	v, ok := r.d.PopFront()
+	if ok {
+		r.notFull.broadcast()
+	}
+	return v, ok
+}
+
+// Peek returns the front element without removing it.
+func (r *RingBuffer[T]) Peek() (T, bool) {
+	return r.At(0)
+}
+
+// At returns the element at index i, counting from the front, in O(1).
+// The boolean is false if i is out of range.
+func (r *RingBuffer[T]) At(i int) (T, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.d.At(i)
+}
+
+// Len returns the number of elements in the buffer.
+func (r *RingBuffer[T]) Len() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.d.Len()
+}
+
+// Cap returns the capacity of the buffer.
+func (r *RingBuffer[T]) Cap() int { return r.capacity }
+
+// IsEmpty reports whether the buffer has no elements.
+func (r *RingBuffer[T]) IsEmpty() bool { return r.Len() == 0 }
+
+// IsFull reports whether the buffer is at capacity.
+func (r *RingBuffer[T]) IsFull() bool { return r.Len() == r.Cap() }
+
+// Policy returns the overflow policy of the buffer.
+func (r *RingBuffer[T]) Policy() OverflowPolicy { return r.policy }
+
+// Dropped returns the number of elements lost to overflow so far: rejected
+// or discarded new elements, and overwritten old ones.
+func (r *RingBuffer[T]) Dropped() uint64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.dropped
+}
+
+// Clear removes all elements. The dropped count is kept.
+func (r *RingBuffer[T]) Clear() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	clear(r.d.buf)
+	r.d.head, r.d.tail, r.d.size = 0, 0, 0
+	r.notFull.broadcast()
+}
+
+// ToSlice returns the elements in FIFO order.
+func (r *RingBuffer[T]) ToSlice() []T {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.d.ToSlice()
+}
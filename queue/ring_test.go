@@ -0,0 +1,204 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+func fill(r *RingBuffer[int], values ...int) {
+	for _, v := range values {
+		r.Push(v)
+	}
+}
+
+func TestRingBufferReject(t *testing.T) {
+	r := NewRingBuffer[int](3, OverflowReject)
+	fill(r, 1, 2, 3)
+	if err := r.Push(4); !errors.Is(err, ErrFull) {
+		t.Errorf("Push on full buffer: err = %v, want ErrFull", err)
+	}
+	if got := r.ToSlice(); !slices.Equal(got, []int{1, 2, 3}) {
+		t.Errorf("contents = %v", got)
+	}
+	if r.Dropped() != 1 {
+		t.Errorf("Dropped() = %d, want 1", r.Dropped())
+	}
+}
+
+func TestRingBufferOverwriteOldest(t *testing.T) {
+	r := NewRingBuffer[int](3, OverflowOverwriteOldest)
+	fill(r, 1, 2, 3, 4, 5)
+	if got := r.ToSlice(); !slices.Equal(got, []int{3, 4, 5}) {
+		t.Errorf("contents = %v, want [3 4 5]", got)
+	}
+	if r.Dropped() != 2 {
+		t.Errorf("Dropped() = %d, want 2", r.Dropped())
+	}
+	if v, _ := r.Pop(); v != 3 {
+		t.Errorf("Pop() = %d, want 3", v)
+	}
+	fill(r, 6, 7, 8, 9)
+	if len(r.d.buf) != 3 {
+		t.Errorf("buffer grew to %d slots, want 3", len(r.d.buf))
+	}
+}
+
+func TestRingBufferDropNewest(t *testing.T) {
+	r := NewRingBuffer[int](3, OverflowDropNewest)
+	fill(r, 1, 2, 3)
+	if err := r.Push(4); err != nil {
+		t.Errorf("Push should drop silently, got %v", err)
+	}
+	if got := r.ToSlice(); !slices.Equal(got, []int{1, 2, 3}) {
+		t.Errorf("contents = %v, want [1 2 3]", got)
+	}
+	if r.Dropped() != 1 {
+		t.Errorf("Dropped() = %d, want 1", r.Dropped())
+	}
+}
+
+func TestRingBufferBlock(t *testing.T) {
+	r := NewRingBuffer[int](2, OverflowBlock)
+	fill(r, 1, 2)
+
+	done := make(chan error)
+	go func() { done <- r.Push(3) }()
+	select {
+	case <-done:
+		t.Fatal("Push should block while the buffer is full")
+	case <-time.After(20 * time.Millisecond):
+	}
+	r.Pop()
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+	if got := r.ToSlice(); !slices.Equal(got, []int{2, 3}) {
+		t.Errorf("contents = %v, want [2 3]", got)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := r.PushContext(ctx, 4); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("PushContext: err = %v, want DeadlineExceeded", err)
+	}
+	if r.Dropped() != 0 {
+		t.Errorf("blocking pushes should not drop, Dropped() = %d", r.Dropped())
+	}
+}
+
+func TestRingBufferAt(t *testing.T) {
+	r := NewRingBuffer[int](4, OverflowOverwriteOldest)
+	fill(r, 1, 2, 3, 4, 5, 6) // wraps around: 3 4 5 6
+	for i, want := range []int{3, 4, 5, 6} {
+		if v, ok := r.At(i); !ok || v != want {
+			t.Errorf("At(%d) = %d, %v, want %d", i, v, ok, want)
+		}
+	}
+	if _, ok := r.At(4); ok {
+		t.Error("At(Len()) should be out of range")
+	}
+	if _, ok := r.At(-1); ok {
+		t.Error("At(-1) should be out of range")
+	}
+	if v, ok := r.Peek(); !ok || v != 3 {
+		t.Errorf("Peek() = %d, %v, want 3", v, ok)
+	}
+}
+
+func TestRingBufferState(t *testing.T) {
+	r := NewRingBuffer[string](2, OverflowReject)
+	if !r.IsEmpty() || r.IsFull() || r.Cap() != 2 || r.Policy() != OverflowReject {
+		t.Error("new buffer state is wrong")
+	}
+	r.Push("a")
+	r.Push("b")
+	if !r.IsFull() || r.Len() != 2 {
+		t.Error("buffer should be full")
+	}
+	r.Push("c")
+	r.Clear()
+	if !r.IsEmpty() || r.Dropped() != 1 {
+		t.Errorf("after Clear: Len() = %d, Dropped() = %d", r.Len(), r.Dropped())
+	}
+	if _, ok := r.Pop(); ok {
+		t.Error("Pop on empty buffer should fail")
+	}
+	if OverflowBlock.String() != "Block" || OverflowPolicy(9).String() != "OverflowPolicy(?)" {
+		t.Error("unexpected policy names")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("zero capacity should panic")
+		}
+	}()
+	NewRingBuffer[int](0, OverflowReject)
+}
+
+func TestRingBufferConcurrent(t *testing.T) {
+	r := NewRingBuffer[int](16, OverflowBlock)
+	const n = 2000
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < n; i++ {
+			r.Push(i)
+		}
+	}()
+	next := 0
+	for next < n {
+		if v, ok := r.Pop(); ok {
+			if v != next {
+				t.Fatalf("Pop() = %d, want %d", v, next)
+			}
+			next++
+		} else {
+			runtime.Gosched()
+		}
+	}
+	wg.Wait()
+}
+
+func TestRingBufferConcurrentClear(t *testing.T) {
+	// Run with -race: Cap and IsFull must not race with Clear.
+	r := NewRingBuffer[int](8, OverflowOverwriteOldest)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 1000; i++ {
+			r.Push(i)
+			if i%10 == 0 {
+				r.Clear()
+				runtime.Gosched()
+			}
+		}
+	}()
+	for running := true; running; {
+		select {
+		case <-done:
+			running = false
+		default:
+		}
+		if r.Cap() != 8 {
+			t.Fatalf("Cap() = %d, want 8", r.Cap())
+		}
+		r.IsFull()
+		runtime.Gosched()
+	}
+	if r.Len() > r.Cap() {
+		t.Errorf("Len() = %d exceeds Cap() = %d", r.Len(), r.Cap())
+	}
+}
+
+func BenchmarkRingBufferOverwrite(b *testing.B) {
+	r := NewRingBuffer[int](1024, OverflowOverwriteOldest)
+	for i := 0; i < b.N; i++ {
+		r.Push(i)
+	}
+}
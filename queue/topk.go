@@ -0,0 +1,89 @@
+package queue
+
+import (
+	"container/heap"
+	"iter"
+	"slices"
+)
+
+// TopK keeps the k best elements offered to it, using O(k) memory however
+// many elements are offered. Internally it is a PriorityQueue with the
+// ordering reversed, so the worst element kept is on top and can be
+// evicted in O(log k).
+type TopK[T any] struct {
+	k    int
+	less func(a, b T) bool
+	pq   *PriorityQueue[T]
+}
+
+// NewTopK creates a TopK keeping the k elements that come first under the
+// provided ordering. The function less must return true when a should come
+// before b, as for NewPriorityQueue. It panics if k is less than 1.
+func NewTopK[T any](k int, less func(a, b T) bool) *TopK[T] {
+	if k < 1 {
+		panic("queue: TopK k must be at least 1")
+	}
+	return &TopK[T]{
+		k:    k,
+		less: less,
+		pq:   NewPriorityQueue(func(a, b T) bool { return less(b, a) }),
+	}
+}
+
+// TopKFromSeq returns a TopK holding the k best elements of seq.
+func TopKFromSeq[T any](seq iter.Seq[T], k int, less func(a, b T) bool) *TopK[T] {
+	t := NewTopK(k, less)
+	t.OfferAll(seq)
+	return t
+}
+
+// Offer considers v for the top k and reports whether it was kept. Once k
+// elements are held, v replaces the worst of them only if it comes strictly
+// before it, so among equal elements the earliest offered are kept.
+func (t *TopK[T]) Offer(v T) bool {
+	if t.pq.Len() < t.k {
+		t.pq.Push(v)
+		return true
+	}
+	if !t.less(v, t.pq.h.data[0]) {
+		return false
+	}
+	t.pq.h.data[0] = v
+	heap.Fix(t.pq.h, 0)
+	return true
+}
+
+// OfferAll offers every element of seq.
+func (t *TopK[T]) OfferAll(seq iter.Seq[T]) {
+	for v := range seq {
+		t.Offer(v)
+	}
+}
+
+// Merge offers every element held by other, for combining partial results
+// computed in parallel. other is not modified, and merging t into itself
+// has no effect. Elements already held win ties against those of other,
+// and other's elements are offered in no particular order, so ties are
+// broken by merge order rather than by the order elements were first
+// offered.
+func (t *TopK[T]) Merge(other *TopK[T]) {
+	if other == t {
+		return
+	}
+	for _, v := range other.pq.h.data {
+		t.Offer(v)
+	}
+}
+
+// Result returns the elements held, best first. The TopK is not modified.
+func (t *TopK[T]) Result() []T {
+	out := t.pq.ToSortedSlice()
+	slices.Reverse(out)
+	return out
+}
+
+// Len returns the number of elements held, at most K.
+func (t *TopK[T]) Len() int { return t.pq.Len() }
+
+// K returns the maximum number of elements held.
+func (t *TopK[T]) K() int { return t.k }
@@ -0,0 +1,144 @@
+package queue
+
+import (
+	"math/rand/v2"
+	"slices"
+	"sync"
+	"testing"
+)
+
+type record struct {
+	id    int
+	score float64
+}
+
+func byScore(a, b record) bool { return a.score > b.score }
+
+func TestTopK(t *testing.T) {
+	top := NewTopK(3, func(a, b int) bool { return a > b })
+	if top.K() != 3 || top.Len() != 0 || len(top.Result()) != 0 {
+		t.Error("new TopK should be empty")
+	}
+	for _, v := range []int{5, 1, 9, 3, 7} {
+		top.Offer(v)
+	}
+	if got, want := top.Result(), []int{9, 7, 5}; !slices.Equal(got, want) {
+		t.Errorf("Result() = %v, want %v", got, want)
+	}
+	if top.Offer(2) {
+		t.Error("an element worse than all kept should be rejected")
+	}
+	if !top.Offer(8) {
+		t.Error("a better element should be kept")
+	}
+	if got, want := top.Result(), []int{9, 8, 7}; !slices.Equal(got, want) {
+		t.Errorf("Result() = %v, want %v", got, want)
+	}
+	if top.Len() != 3 {
+		t.Errorf("Len() = %d, want 3", top.Len())
+	}
+}
+
+func TestTopKTies(t *testing.T) {
+	top := NewTopK(2, byScore)
+	for i := 0; i < 5; i++ {
+		top.Offer(record{i, 1})
+	}
+	got := top.Result()
+	if len(got) != 2 || got[0].id > 1 || got[1].id > 1 {
+		t.Errorf("Result() = %v, want the two earliest ties", got)
+	}
+}
+
+func TestTopKMatchesSort(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	records := make([]record, 100000)
+	for i := range records {
+		records[i] = record{i, r.Float64()}
+	}
+	top := TopKFromSeq(slices.Values(records), 100, byScore)
+
+	sorted := slices.Clone(records)
+	slices.SortStableFunc(sorted, func(a, b record) int {
+		switch {
+		case a.score > b.score:
+			return -1
+		case a.score < b.score:
+			return 1
+		}
+		return 0
+	})
+	if got := top.Result(); !slices.Equal(got, sorted[:100]) {
+		t.Errorf("top 100 differs from sorting: first %v vs %v", got[0], sorted[0])
+	}
+}
+
+func TestTopKMerge(t *testing.T) {
+	const workers, perWorker, k = 4, 10000, 50
+	r := rand.New(rand.NewPCG(3, 4))
+	values := make([]int, workers*perWorker)
+	for i := range values {
+		values[i] = r.IntN(1 << 30)
+	}
+
+	less := func(a, b int) bool { return a < b }
+	partial := make([]*TopK[int], workers)
+	var wg sync.WaitGroup
+	for w := range partial {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			partial[w] = TopKFromSeq(slices.Values(values[w*perWorker:(w+1)*perWorker]), k, less)
+		}()
+	}
+	wg.Wait()
+
+	merged := NewTopK(k, less)
+	for _, p := range partial {
+		merged.Merge(p)
+	}
+	if partial[0].Len() != k {
+		t.Error("Merge should not modify its argument")
+	}
+
+	slices.Sort(values)
+	if got := merged.Result(); !slices.Equal(got, values[:k]) {
+		t.Errorf("merged result differs from the global top %d", k)
+	}
+}
+
+func TestTopKMergeSelf(t *testing.T) {
+	for _, values := range [][]int{{5, 1, 9, 7}, {5, 1}} {
+		top := NewTopK(3, func(a, b int) bool { return a > b })
+		for _, v := range values {
+			top.Offer(v)
+		}
+		want := top.Result()
+		top.Merge(top)
+		if got := top.Result(); !slices.Equal(got, want) {
+			t.Errorf("Merge(self) of %v = %v, want %v", values, got, want)
+		}
+	}
+}
+
+func TestTopKPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("k = 0 should panic")
+		}
+	}()
+	NewTopK(0, func(a, b int) bool { return a < b })
+}
+
+func BenchmarkTopK(b *testing.B) {
+	r := rand.New(rand.NewPCG(1, 1))
+	values := make([]float64, 1<<16)
+	for i := range values {
+		values[i] = r.Float64()
+	}
+	top := NewTopK(100, func(a, b float64) bool { return a > b })
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		top.Offer(values[i&(len(values)-1)])
+	}
+}